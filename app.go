@@ -2,23 +2,35 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"syscall"
 )
 
 // App is the top-level application instance
 type App struct {
-	mutex  sync.RWMutex
-	pool   sync.Pool
-	config *Config
-	router *Router
-	server *http.Server
+	mutex   sync.RWMutex
+	pool    sync.Pool
+	config  *Config
+	router  *Router
+	servers []*http.Server
+
+	hooks          hooks
+	trustedProxies []netip.Prefix
 }
 
 // New creates an instance of App.
+// It panics if any of the TrustedProxies of the configuration is not
+// a valid address or CIDR range or if the ProxyHeader is not supported.
 func New(configure ...func(*Config)) (app *App) {
 	app = &App{
 		router: NewRouter(),
@@ -28,12 +40,21 @@ func New(configure ...func(*Config)) (app *App) {
 			},
 		},
 		config: &Config{
-			MaxBodySize: DefaultMaxBodySize,
+			MaxBodySize:       DefaultMaxBodySize,
+			ReadTimeout:       DefaultReadTimeout,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+			MaxHeaderBytes:    DefaultMaxHeaderBytes,
+			GracePeriod:       DefaultGracePeriod,
+			ProxyHeader:       HeaderXForwardedFor,
 		},
 	}
 	for _, c := range configure {
 		c(app.config)
 	}
+	app.trustedProxies = parseTrustedProxies(app.config.TrustedProxies)
+	app.config.ProxyHeader = parseProxyHeader(app.config.ProxyHeader)
 	return
 }
 
@@ -51,9 +72,23 @@ func (app *App) NewContext(w http.ResponseWriter, r *http.Request) *Context {
 	}
 }
 
-// Server returns the http.Server instance of the application
+// Server returns the first http.Server instance of the application.
+// It returns nil if the application is not being served.
 func (app *App) Server() *http.Server {
-	return app.server
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+	if len(app.servers) == 0 {
+		return nil
+	}
+	return app.servers[0]
+}
+
+// Servers returns the http.Server instances of the application,
+// one for each listener the application is being served on.
+func (app *App) Servers() []*http.Server {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+	return append([]*http.Server(nil), app.servers...)
 }
 
 // Config returns the Config instance of the application
@@ -119,8 +154,17 @@ func (app *App) Trace(path string, handler HandlerFunc) {
 }
 
 // Handle adds a new Route with the specified http method to the Router of the application.
+// It panics with the errors of the hooks registered by `OnRoute()`, if any.
 func (app *App) Handle(method, path string, handler HandlerFunc) {
-	app.router.Handle(Route{Method: method, Path: path, Handler: handler})
+	app.handle(Route{Method: method, Path: path, Handler: handler})
+}
+
+// handle adds the Route to the Router and executes the route hooks.
+func (app *App) handle(route Route) {
+	app.router.Handle(route)
+	if err := app.runRouteHooks(route); err != nil {
+		panic(err)
+	}
 }
 
 // All adds a new Route on all HTTP methods to the Router of the application.
@@ -131,8 +175,24 @@ func (app *App) All(path string, handler HandlerFunc) {
 }
 
 // Static adds a new Route to the Router of the application, which serves static files.
-func (app *App) Static(path, root string) {
-	app.router.Handle(Route{Method: http.MethodGet, Path: path, Handler: FileHandler(root)})
+// The path of the route is stripped from the request path to look up the file.
+func (app *App) Static(path, root string, configure ...func(*StaticConfig)) {
+	app.StaticFS(path, os.DirFS(root), configure...)
+}
+
+// StaticFS adds a new Route to the Router of the application, which serves
+// static files from the file system, e.g. an embed.FS.
+// The path of the route is stripped from the request path to look up the file.
+func (app *App) StaticFS(path string, fsys fs.FS, configure ...func(*StaticConfig)) {
+	// The route is registered with a trailing slash, such that the router
+	// redirects the bare path to the directory, instead of redirecting the
+	// directory back to the bare path.
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	prefix := func(c *StaticConfig) { c.Prefix = path }
+	handler := FileSystemHandler(fsys, append([]func(*StaticConfig){prefix}, configure...)...)
+	app.handle(Route{Method: http.MethodGet, Path: path, Handler: handler})
 }
 
 // Use adds a Middleware to the router.
@@ -142,6 +202,12 @@ func (app *App) Use(middlewares ...Middleware) {
 	app.router.Use(middlewares...)
 }
 
+// Pre adds a Middleware to the router, which is executed before the request
+// is routed, e.g. to rewrite the method or path of the request.
+func (app *App) Pre(middlewares ...Middleware) {
+	app.router.Pre(middlewares...)
+}
+
 // Mount adds a new app which handles requests on the specified pattern.
 func (app *App) Mount(pattern string, group *App) {
 	app.All(pattern, WithContext(http.StripPrefix(pattern, group)))
@@ -182,6 +248,108 @@ func (app *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// newServer creates the http.Server serving the application on the
+// address, applying the timeouts, limits and callbacks of the Config.
+func (app *App) newServer(addr string) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           app,
+		ReadTimeout:       app.config.ReadTimeout,
+		ReadHeaderTimeout: app.config.ReadHeaderTimeout,
+		WriteTimeout:      app.config.WriteTimeout,
+		IdleTimeout:       app.config.IdleTimeout,
+		MaxHeaderBytes:    app.config.MaxHeaderBytes,
+		ErrorLog:          app.config.ErrorLog,
+		ConnState:         app.config.ConnState,
+		BaseContext:       app.config.BaseContext,
+	}
+}
+
+// start executes the start hooks and opens a listener for each of the
+// addresses, before creating the servers and executing the listen hooks.
+// The servers are added to the application before they serve the listeners,
+// such that they can be shut down at any point in time.
+// The listeners are closed and the servers are removed again if any of the
+// listeners can't be opened or any of the listen hooks fails.
+func (app *App) start(addrs []string, listen func(addr string) (net.Listener, error)) ([]*http.Server, []net.Listener, error) {
+	if err := app.runStartHooks(); err != nil {
+		return nil, nil, err
+	}
+
+	listeners := make([]net.Listener, 0, len(addrs))
+	for _, addr := range addrs {
+		ln, err := listen(addr)
+		if err != nil {
+			closeListeners(listeners)
+			app.abort(nil)
+			return nil, nil, err
+		}
+		listeners = append(listeners, ln)
+	}
+
+	servers := make([]*http.Server, len(addrs))
+	app.mutex.Lock()
+	for i, addr := range addrs {
+		servers[i] = app.newServer(addr)
+	}
+	app.servers = append(app.servers, servers...)
+	app.mutex.Unlock()
+
+	errs := make([]error, 0, len(listeners))
+	for _, ln := range listeners {
+		errs = append(errs, app.runListenHooks(ln.Addr().String()))
+	}
+	if err := joinErrors(errs...); err != nil {
+		closeListeners(listeners)
+		app.abort(servers)
+		return nil, nil, err
+	}
+	return servers, listeners, nil
+}
+
+// abort removes the servers, which failed to start, from the application.
+// If no other server is running, the start hooks are executed again the
+// next time the application is served.
+func (app *App) abort(servers []*http.Server) {
+	if _, running := app.remove(servers); running == 0 {
+		app.hooks.reset()
+	}
+}
+
+// remove removes the servers from the application. It returns the servers,
+// which were still part of the application, and the number of the servers,
+// which remain running.
+func (app *App) remove(servers []*http.Server) ([]*http.Server, int) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	removed := make([]*http.Server, 0, len(servers))
+	running := make([]*http.Server, 0, len(app.servers))
+	for _, server := range app.servers {
+		found := false
+		for _, s := range servers {
+			if s == server {
+				found = true
+				break
+			}
+		}
+		if found {
+			removed = append(removed, server)
+		} else {
+			running = append(running, server)
+		}
+	}
+	app.servers = running
+	return removed, len(running)
+}
+
+// closeListeners closes all of the listeners.
+func closeListeners(listeners []net.Listener) {
+	for _, ln := range listeners {
+		ln.Close()
+	}
+}
+
 // Serve accepts incoming HTTP connections on the listener l,
 // creating a new service goroutine for each. The service goroutines
 // read requests and then call handler to reply to them.
@@ -192,12 +360,16 @@ func (app *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // connections and they were configured with "h2" in the TLS
 // Config.NextProtos.
 //
+// Serve may be called multiple times to serve the application on
+// multiple listeners, which are all shut down by `Shutdown()`.
+//
 // Serve always returns a non-nil error.
 func (app *App) Serve(l net.Listener) error {
-	app.mutex.Lock()
-	app.server = &http.Server{Handler: app}
-	app.mutex.Unlock()
-	return app.server.Serve(l)
+	servers, _, err := app.start([]string{""}, func(string) (net.Listener, error) { return l, nil })
+	if err != nil {
+		return err
+	}
+	return servers[0].Serve(l)
 }
 
 // ServeTLS accepts incoming HTTPS connections on the listener l,
@@ -213,24 +385,34 @@ func (app *App) Serve(l net.Listener) error {
 //
 // ServeTLS always returns a non-nil error.
 func (app *App) ServeTLS(l net.Listener, certFile, keyFile string) error {
-	app.mutex.Lock()
-	app.server = &http.Server{Handler: app}
-	app.mutex.Unlock()
-	return app.server.ServeTLS(l, certFile, keyFile)
+	servers, _, err := app.start([]string{""}, func(string) (net.Listener, error) { return l, nil })
+	if err != nil {
+		return err
+	}
+	return servers[0].ServeTLS(l, certFile, keyFile)
 }
 
-// Listen listens on the TCP network address addr and then calls
+// Listen listens on the network address addr and then calls
 // Serve with handler to handle requests on incoming connections.
-// Accepted connections are configured to enable TCP keep-alives.
+// Accepted TCP connections are configured to enable TCP keep-alives.
+//
+// The address is either a TCP network address, e.g. ":8080", a Unix domain
+// socket prefixed by "unix:", e.g. "unix:/run/app.sock", an inherited file
+// descriptor prefixed by "fd:", e.g. "fd:3", or a named file descriptor passed
+// by the socket activation of systemd prefixed by "systemd:", e.g. "systemd:http".
 //
 // The handler is typically nil, in which case the DefaultServeMux is used.
 //
 // ListenAndServe always returns a non-nil error.
 func (app *App) Listen(addr string) error {
-	app.mutex.Lock()
-	app.server = &http.Server{Addr: addr, Handler: app}
-	app.mutex.Unlock()
-	return app.server.ListenAndServe()
+	if addr == "" {
+		addr = ":http"
+	}
+	servers, listeners, err := app.start([]string{addr}, listen)
+	if err != nil {
+		return err
+	}
+	return servers[0].Serve(listeners[0])
 }
 
 // ListenTLS acts identically to Listen, except that it
@@ -239,19 +421,97 @@ func (app *App) Listen(addr string) error {
 // is signed by a certificate authority, the certFile should be the concatenation
 // of the server's certificate, any intermediates, and the CA's certificate.
 func (app *App) ListenTLS(addr, certFile, keyFile string) error {
-	app.mutex.Lock()
-	app.server = &http.Server{Addr: addr, Handler: app}
-	app.mutex.Unlock()
-	return app.server.ListenAndServeTLS(certFile, keyFile)
+	if addr == "" {
+		addr = ":https"
+	}
+	servers, listeners, err := app.start([]string{addr}, listen)
+	if err != nil {
+		return err
+	}
+	return servers[0].ServeTLS(listeners[0], certFile, keyFile)
 }
 
-// Shutdown gracefully shuts down the server without interrupting any
+// Run listens on the network addresses and serves the application until the
+// context is canceled, any of the servers fails or the process receives an
+// interrupt (SIGINT) or termination (SIGTERM) signal. Afterwards, the servers
+// are shut down gracefully, waiting at most for the GracePeriod of the Config
+// for in-flight requests to complete, see `Shutdown()`. The connections, which
+// are still active after the GracePeriod, are closed forcibly.
+// The format of the addresses is described by `Listen()`. If no address is
+// provided, the application is served on ":http".
+//
+// Run returns nil after a graceful shutdown, including a shutdown initiated
+// by calling `Shutdown()`. Otherwise, it returns the errors
+// that prevented the servers from starting or shutting down gracefully.
+func (app *App) Run(ctx context.Context, addrs ...string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(addrs) == 0 {
+		addrs = []string{":http"}
+	}
+
+	servers, listeners, err := app.start(addrs, listen)
+	if err != nil {
+		return err
+	}
+
+	errc := make(chan error, len(servers))
+	for i := range servers {
+		go func(server *http.Server, ln net.Listener) {
+			errc <- server.Serve(ln)
+		}(servers[i], listeners[i])
+	}
+
+	errs := make([]error, 0, len(servers)+1)
+	select {
+	case err := <-errc:
+		errs = append(errs, err)
+	case <-ctx.Done():
+	}
+
+	// Restore the default behavior of the signals,
+	// such that a second signal terminates the process.
+	stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.config.GracePeriod)
+	defer cancel()
+
+	// Only the servers started by Run are shut down. They may have been
+	// shut down already by `Shutdown()`, which is considered a clean exit.
+	own, running := app.remove(servers)
+	if len(own) > 0 {
+		err = app.shutdown(ctx, own, running == 0)
+
+		// The active connections are closed forcibly,
+		// once the grace period is exceeded.
+		if ctx.Err() != nil {
+			for _, server := range own {
+				server.Close()
+			}
+		}
+	}
+
+	for len(errs) < len(servers) {
+		errs = append(errs, <-errc)
+	}
+
+	for i, serr := range errs {
+		if errors.Is(serr, http.ErrServerClosed) {
+			errs[i] = nil
+		}
+	}
+	return joinErrors(append(errs, err)...)
+}
+
+// Shutdown gracefully shuts down the servers without interrupting any
 // active connections. Shutdown works by first closing all open
 // listeners, then closing all idle connections, and then waiting
 // indefinitely for connections to return to idle and then shut down.
 // If the provided context expires before the shutdown is complete,
 // Shutdown returns the context's error, otherwise it returns any
 // error returned from closing the Server's underlying Listener(s).
+// The servers are shut down concurrently.
 //
 // When Shutdown is called, Serve, ListenAndServe, and
 // ListenAndServeTLS immediately return ErrServerClosed. Make sure the
@@ -265,11 +525,55 @@ func (app *App) ListenTLS(addr, certFile, keyFile string) error {
 //
 // Once Shutdown has been called on a server, it may not be reused;
 // future calls to methods such as Serve will return ErrServerClosed.
+// The servers are removed from the application, such that it can be
+// served again afterwards.
+//
+// After the servers were shut down, the hooks registered by `OnShutdown()`
+// are executed in the order they were registered. The errors of the servers
+// and the hooks are aggregated into the returned error.
 func (app *App) Shutdown(ctx context.Context) error {
-	app.mutex.RLock()
-	defer app.mutex.RUnlock()
-	if app.server == nil {
+	app.mutex.Lock()
+	servers := app.servers
+	app.servers = nil
+	app.mutex.Unlock()
+
+	if len(servers) == 0 {
 		return fmt.Errorf("Shutdown: Server is not running")
 	}
-	return app.server.Shutdown(ctx)
+
+	return app.shutdown(ctx, servers, true)
+}
+
+// shutdown gracefully shuts down the servers concurrently. If they were the
+// last servers of the application, the shutdown hooks are executed afterwards.
+func (app *App) shutdown(ctx context.Context, servers []*http.Server, last bool) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(servers))
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server *http.Server) {
+			defer wg.Done()
+			errs[i] = server.Shutdown(ctx)
+		}(i, server)
+	}
+	wg.Wait()
+
+	// Each server returns the error of the context, if it expires
+	// before the shutdown is complete, which is only reported once.
+	reported := false
+	for i, err := range errs {
+		if err != nil && err == ctx.Err() {
+			if reported {
+				errs[i] = nil
+			}
+			reported = true
+		}
+	}
+
+	if !last {
+		return joinErrors(errs...)
+	}
+
+	app.hooks.reset()
+	return joinErrors(append(errs, app.runShutdownHooks(ctx))...)
 }
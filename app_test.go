@@ -9,12 +9,16 @@
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"io"
+	"log"
 	"math/big"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 	"time"
 )
 
@@ -35,6 +39,46 @@ func TestAppConfig(t *testing.T) {
 	assertEqual(t, 1024, app.Config().MaxBodySize)
 }
 
+func TestAppNewServer(t *testing.T) {
+	app := New()
+	server := app.newServer(":8000")
+
+	assertEqual(t, ":8000", server.Addr)
+	assertEqual(t, app, server.Handler)
+	assertEqual(t, DefaultReadTimeout, server.ReadTimeout)
+	assertEqual(t, DefaultReadHeaderTimeout, server.ReadHeaderTimeout)
+	assertEqual(t, DefaultWriteTimeout, server.WriteTimeout)
+	assertEqual(t, DefaultIdleTimeout, server.IdleTimeout)
+	assertEqual(t, DefaultMaxHeaderBytes, server.MaxHeaderBytes)
+	assertNil(t, server.ErrorLog)
+	assertNil(t, server.ConnState)
+	assertNil(t, server.BaseContext)
+
+	logger := log.New(io.Discard, "", 0)
+	ctx := context.WithValue(context.Background(), "foo", "bar")
+	app = New(func(c *Config) {
+		c.ReadTimeout = 1 * time.Second
+		c.ReadHeaderTimeout = 2 * time.Second
+		c.WriteTimeout = 0
+		c.IdleTimeout = 3 * time.Second
+		c.MaxHeaderBytes = 1024
+		c.ErrorLog = logger
+		c.ConnState = func(net.Conn, http.ConnState) {}
+		c.BaseContext = func(net.Listener) context.Context { return ctx }
+	})
+	server = app.newServer("")
+
+	assertEqual(t, "", server.Addr)
+	assertEqual(t, 1*time.Second, server.ReadTimeout)
+	assertEqual(t, 2*time.Second, server.ReadHeaderTimeout)
+	assertEqual(t, time.Duration(0), server.WriteTimeout)
+	assertEqual(t, 3*time.Second, server.IdleTimeout)
+	assertEqual(t, 1024, server.MaxHeaderBytes)
+	assertEqual(t, logger, server.ErrorLog)
+	assertNotNil(t, server.ConnState)
+	assertEqual(t, ctx, server.BaseContext(nil))
+}
+
 func TestAppServer(t *testing.T) {
 	app := New()
 
@@ -227,6 +271,319 @@ func TestAppShutdown(t *testing.T) {
 	assertEqual(t, "Shutdown: Server is not running", err.Error())
 }
 
+func TestAppShutdownHooks(t *testing.T) {
+	app := New()
+
+	ln, err := net.Listen("tcp", "0.0.0.0:8000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := make([]int, 0)
+	app.OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, 1)
+		return errors.New("Error")
+	}, func(ctx context.Context) error {
+		calls = append(calls, 2)
+		return errors.New("Other")
+	})
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Serve(ln)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	err = app.Shutdown(context.Background())
+	assertEqual(t, "Error; Other", err.Error())
+	assertEqual(t, []int{1, 2}, calls)
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+}
+
+func TestAppRun(t *testing.T) {
+	app := New(func(c *Config) {
+		c.GracePeriod = time.Second
+	})
+
+	started := make(chan struct{})
+	app.Get("/", func(c *Context) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return c.Text(http.StatusOK, "Hello, world!")
+	})
+
+	calls := make([]int, 0)
+	app.OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, 1)
+		return nil
+	}, func(ctx context.Context) error {
+		calls = append(calls, 2)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Run(ctx, "0.0.0.0:8000")
+	}()
+
+	body := make(chan string)
+	go func() {
+		for {
+			res, err := http.Get("http://0.0.0.0:8000")
+			if err != nil {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+			b, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+			body <- string(b)
+			return
+		}
+	}()
+
+	// The in-flight request is completed during the shutdown.
+	<-started
+	cancel()
+
+	assertEqual(t, "Hello, world!", <-body)
+	assertNil(t, <-cerr)
+	assertEqual(t, []int{1, 2}, calls)
+}
+
+func TestAppRunSignal(t *testing.T) {
+	app := New()
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Run(context.Background(), "0.0.0.0:8000")
+	}()
+
+	for {
+		if _, err := http.Get("http://0.0.0.0:8000"); err == nil {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	assertNil(t, <-cerr)
+}
+
+func TestAppRunShutdown(t *testing.T) {
+	app := New()
+
+	calls := 0
+	app.OnShutdown(func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	listening := make(chan struct{})
+	app.OnListen(func(addr string) error {
+		close(listening)
+		return nil
+	})
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Run(context.Background(), "127.0.0.1:0")
+	}()
+
+	// A shutdown initiated by Shutdown is a graceful shutdown of Run.
+	<-listening
+	assertNil(t, app.Shutdown(context.Background()))
+	assertNil(t, <-cerr)
+	assertEqual(t, 1, calls)
+}
+
+func TestAppRunGracePeriod(t *testing.T) {
+	app := New(func(c *Config) {
+		c.GracePeriod = 50 * time.Millisecond
+	})
+
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+	app.Get("/", func(c *Context) error {
+		close(started)
+		<-c.Request.Context().Done()
+		close(aborted)
+		return nil
+	})
+
+	addrs := make(chan string, 1)
+	app.OnListen(func(addr string) error {
+		addrs <- addr
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Run(ctx, "127.0.0.1:0")
+	}()
+
+	res := make(chan error)
+	go func() {
+		_, err := http.Get("http://" + <-addrs)
+		res <- err
+	}()
+
+	// The connection of the in-flight request is closed
+	// forcibly, once the grace period is exceeded.
+	<-started
+	cancel()
+
+	assertEqual(t, context.DeadlineExceeded, <-cerr)
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("Handler not aborted after the grace period")
+	}
+	assertNotNil(t, <-res)
+}
+
+func TestAppRunListenError(t *testing.T) {
+	app := New()
+
+	err := app.Run(context.Background(), "invalid")
+	assertNotNil(t, err)
+
+	// Opened listeners are closed, if any of the addresses is invalid.
+	sock := filepath.Join(t.TempDir(), "app.sock")
+	err = app.Run(context.Background(), "unix:"+sock, "invalid")
+	assertNotNil(t, err)
+	_, err = os.Stat(sock)
+	assertEqual(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestAppRunMultiple(t *testing.T) {
+	app := New()
+	app.Get("/", func(c *Context) error {
+		return c.Text(http.StatusOK, "Hello, world!")
+	})
+
+	starts := 0
+	app.OnStart(func() error {
+		starts++
+		return nil
+	})
+
+	addrs := make(chan string, 2)
+	app.OnListen(func(addr string) error {
+		addrs <- addr
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sock := filepath.Join(t.TempDir(), "app.sock")
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Run(ctx, "127.0.0.1:0", "unix:"+sock)
+	}()
+
+	addr := <-addrs
+	assertEqual(t, sock, <-addrs)
+	assertEqual(t, 1, starts)
+	assertEqual(t, 2, len(app.Servers()))
+
+	res, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assertEqual(t, "Hello, world!", string(b))
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return new(net.Dialer).DialContext(ctx, "unix", sock)
+			},
+		},
+	}
+	res, err = client.Get("http://unix/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	assertEqual(t, "Hello, world!", string(b))
+
+	cancel()
+	assertNil(t, <-cerr)
+	assertNil(t, app.Server())
+
+	// Both listeners are closed by the shutdown.
+	_, err = net.Dial("tcp", addr)
+	assertNotNil(t, err)
+	_, err = os.Stat(sock)
+	assertEqual(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestAppShutdownMultiple(t *testing.T) {
+	app := New()
+
+	starts := 0
+	app.OnStart(func() error {
+		starts++
+		return nil
+	})
+
+	cerr := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			cerr <- app.Serve(ln)
+		}()
+	}
+
+	for len(app.Servers()) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	assertEqual(t, 1, starts)
+
+	assertNil(t, app.Shutdown(context.Background()))
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+	assertEqual(t, 0, len(app.Servers()))
+
+	err := app.Shutdown(context.Background())
+	assertEqual(t, "Shutdown: Server is not running", err.Error())
+
+	// The start hooks are executed again, once the application is served again.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		cerr <- app.Serve(ln)
+	}()
+	for app.Server() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	assertEqual(t, 2, starts)
+	assertNil(t, app.Shutdown(context.Background()))
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+}
+
 func TestApp(t *testing.T) {
 	tests := []struct {
 		path    string
@@ -381,6 +738,7 @@ func TestAppAll(t *testing.T) {
 
 func TestAppStatic(t *testing.T) {
 	tests := []struct {
+		prefix string
 		dir    string
 		path   string
 		status int
@@ -395,11 +753,90 @@ func TestAppStatic(t *testing.T) {
 			path:   "/labeler.yml",
 			status: http.StatusNotFound,
 		},
+		{
+			prefix: "/assets",
+			dir:    ".github/",
+			path:   "/assets/labeler.yml",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		prefix := testcase.prefix
+		if prefix == "" {
+			prefix = "/"
+		}
+
+		app := New()
+		app.Static(prefix, testcase.dir)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", testcase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		app.ServeHTTP(rr, req)
+
+		assertEqual(t, testcase.status, rr.Code)
+	}
+}
+
+func TestAppStaticFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":  {Data: []byte("index")},
+		"labeler.yml": {Data: []byte("foo: bar")},
+	}
+
+	tests := []struct {
+		prefix   string
+		path     string
+		status   int
+		location string
+		body     string
+	}{
+		{
+			path:   "/labeler.yml",
+			status: http.StatusOK,
+		},
+		{
+			path:   "/missing.yml",
+			status: http.StatusNotFound,
+		},
+		{
+			prefix:   "/assets",
+			path:     "/assets",
+			status:   http.StatusMovedPermanently,
+			location: "/assets/",
+		},
+		{
+			prefix: "/assets",
+			path:   "/assets/",
+			status: http.StatusOK,
+			body:   "index",
+		},
+		{
+			prefix: "/assets/",
+			path:   "/assets/",
+			status: http.StatusOK,
+			body:   "index",
+		},
+		{
+			prefix: "/assets",
+			path:   "/assets/labeler.yml",
+			status: http.StatusOK,
+			body:   "foo: bar",
+		},
 	}
 
 	for _, testcase := range tests {
+		prefix := testcase.prefix
+		if prefix == "" {
+			prefix = "/"
+		}
+
 		app := New()
-		app.Static("/", testcase.dir)
+		app.StaticFS(prefix, fsys)
 
 		rr := httptest.NewRecorder()
 		req, err := http.NewRequest("GET", testcase.path, nil)
@@ -410,6 +847,12 @@ func TestAppStatic(t *testing.T) {
 		app.ServeHTTP(rr, req)
 
 		assertEqual(t, testcase.status, rr.Code)
+		if testcase.location != "" {
+			assertEqual(t, testcase.location, rr.Header().Get(HeaderLocation))
+		}
+		if testcase.body != "" {
+			assertEqual(t, testcase.body, rr.Body.String())
+		}
 	}
 }
 
@@ -1,5 +1,13 @@
 package lungo
 
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
 // Config defines the configuration options for the application
 type Config struct {
 	// Maximum number of  bytes to read from the request body.
@@ -10,10 +18,208 @@ type Config struct {
 	//
 	// Default: 1 * 1024 * 1024 = 1048576 Bytes = 1MiB
 	MaxBodySize int `json:"max_body_size"`
+
+	// Secret keys used to sign and encrypt cookies, see `c.SetSignedCookie()`
+	// and `c.SetEncryptedCookie()`. New cookies are always encoded using the
+	// first key, while all keys are accepted when decoding a cookie. Prepend
+	// a new key to rotate the keys and remove old keys once the cookies
+	// encoded with them have expired. The keys are excluded from the JSON
+	// encoding of the Config, such that they are not leaked by config dumps.
+	//
+	// Default: nil
+	CookieKeys [][]byte `json:"-"`
+
+	// Addresses or CIDR ranges of the reverse proxies in front of the
+	// application, e.g. "10.0.0.0/8" or "127.0.0.1". The ProxyHeader is
+	// only taken into account by `c.RealIP()`, `c.Scheme()` and `c.Host()`
+	// if the request was received from a trusted proxy.
+	//
+	// Default: nil
+	TrustedProxies []string `json:"trusted_proxies"`
+
+	// The header, which is set by the trusted proxies to forward the
+	// address of the client. Other headers are ignored, since they may be
+	// passed through unchanged by the proxies and thus can be spoofed by
+	// clients. It must be one of:
+	//
+	//   - "X-Forwarded-For": The address of the client is resolved from the
+	//     `X-Forwarded-For` header, the scheme from the `X-Forwarded-Proto`
+	//     header and the host from the `X-Forwarded-Host` header.
+	//   - "X-Real-IP": Like "X-Forwarded-For", except that the address of the
+	//     client is taken from the `X-Real-IP` header.
+	//   - "Forwarded": The address of the client, the scheme and the host
+	//     are resolved from the `Forwarded` header (RFC 7239).
+	//
+	// Default: "X-Forwarded-For"
+	ProxyHeader string `json:"proxy_header"`
+
+	// Maximum duration for reading the entire request, including the body.
+	// A zero or negative value means there will be no timeout.
+	//
+	// Default: 30 * time.Second
+	ReadTimeout time.Duration `json:"read_timeout"`
+
+	// Maximum duration for reading the request headers.
+	// A zero or negative value means the ReadTimeout is used.
+	//
+	// Default: 10 * time.Second
+	ReadHeaderTimeout time.Duration `json:"read_header_timeout"`
+
+	// Maximum duration before timing out writes of the response.
+	// A zero or negative value means there will be no timeout.
+	// Set this value to 0 for long-lived streaming responses.
+	//
+	// Default: 30 * time.Second
+	WriteTimeout time.Duration `json:"write_timeout"`
+
+	// Maximum duration to wait for the next request when keep-alives
+	// are enabled. A zero or negative value means the ReadTimeout is used.
+	//
+	// Default: 120 * time.Second
+	IdleTimeout time.Duration `json:"idle_timeout"`
+
+	// Maximum number of bytes the server will read parsing the
+	// request header's keys and values, including the request line.
+	//
+	// Default: 1 * 1024 * 1024 = 1048576 Bytes = 1MiB
+	MaxHeaderBytes int `json:"max_header_bytes"`
+
+	// Logger for errors accepting connections, unexpected behavior from
+	// handlers, and underlying FileSystem errors.
+	// If nil, logging is done via the log package's standard logger.
+	//
+	// Default: nil
+	ErrorLog *log.Logger `json:"-"`
+
+	// Callback function that is called when a client connection changes state.
+	// See the ConnState type and associated constants of net/http for details.
+	//
+	// Default: nil
+	ConnState func(net.Conn, http.ConnState) `json:"-"`
+
+	// Callback function that returns the base context for incoming requests
+	// on the listener. If nil, the default is context.Background().
+	//
+	// Default: nil
+	BaseContext func(net.Listener) context.Context `json:"-"`
+
+	// Maximum duration to wait for in-flight requests to complete
+	// when `app.Run()` shuts down the server gracefully. Afterwards,
+	// the remaining connections are closed forcibly.
+	//
+	// Default: 10 * time.Second
+	GracePeriod time.Duration `json:"grace_period"`
 }
 
 const (
 	// DefaultMaxBodySize defines the default maximum number of bytes
 	// to read from a http request body.
 	DefaultMaxBodySize = 1048576 // 1 * 1024 * 1024 = 1048576 Bytes = 1MiB
+
+	// DefaultReadTimeout defines the default maximum duration
+	// for reading the entire request, including the body.
+	DefaultReadTimeout = 30 * time.Second
+
+	// DefaultReadHeaderTimeout defines the default maximum
+	// duration for reading the request headers.
+	DefaultReadHeaderTimeout = 10 * time.Second
+
+	// DefaultWriteTimeout defines the default maximum duration
+	// before timing out writes of the response.
+	DefaultWriteTimeout = 30 * time.Second
+
+	// DefaultIdleTimeout defines the default maximum duration to
+	// wait for the next request when keep-alives are enabled.
+	DefaultIdleTimeout = 120 * time.Second
+
+	// DefaultMaxHeaderBytes defines the default maximum number
+	// of bytes to read from the request headers.
+	DefaultMaxHeaderBytes = http.DefaultMaxHeaderBytes // 1 * 1024 * 1024 = 1048576 Bytes = 1MiB
+
+	// DefaultGracePeriod defines the default maximum duration
+	// to wait for in-flight requests during a graceful shutdown.
+	DefaultGracePeriod = 10 * time.Second
 )
+
+// StaticConfig defines the configuration options for serving static files
+type StaticConfig struct {
+	// Prefix defines the path prefix which is stripped from the request
+	// path before looking up the file, e.g. a request to "/assets/app.js"
+	// with the prefix "/assets" serves the file "app.js".
+	//
+	// The prefix is set to the route path by `app.Static()` and `app.StaticFS()`.
+	//
+	// Optional. Default: ""
+	Prefix string `json:"prefix"`
+
+	// Index defines the name of the file to serve for requests to a directory.
+	// Set this value to "" to disable serving index files.
+	//
+	// Optional. Default: "index.html"
+	Index string `json:"index"`
+
+	// Browse defines whether or not a listing of the directory contents
+	// is served for requests to a directory without an index file.
+	//
+	// Optional. Default: false
+	Browse bool `json:"browse"`
+
+	// Hidden defines whether or not hidden files and directories,
+	// whose name starts with a dot, e.g. ".env", are served.
+	//
+	// Optional. Default: false
+	Hidden bool `json:"hidden"`
+
+	// Fallback defines the file to serve, if the requested file does not
+	// exist. This is used by single page applications with client-side
+	// routing, which serve "index.html" for all paths.
+	//
+	// Optional. Default: ""
+	Fallback string `json:"fallback"`
+
+	// Precompressed defines whether or not precompressed variants of a file
+	// are served, if supported by the client according to the `Accept-Encoding`
+	// header. The variants are looked up next to the file with the extension
+	// ".br" for brotli and ".gz" for gzip, e.g. "app.js.br" and "app.js.gz".
+	//
+	// Optional. Default: false
+	Precompressed bool `json:"precompressed"`
+
+	// Fingerprint defines a regular expression matching the names of
+	// fingerprinted files, which contain a hash of their content, e.g.
+	// "app.3f2a9c1b.js". These files never change and thus are served
+	// with a long-lived `Cache-Control` header. Caching of fingerprinted
+	// files is disabled if this value is "". Set it to FingerprintPattern
+	// to match a hash of at least 8 hex digits before the file extension.
+	// Make sure the pattern does not match the names of other files, e.g.
+	// "report-20221231.pdf", since clients won't revalidate them.
+	//
+	// see: https://golang.org/pkg/regexp/syntax/
+	//
+	// Optional. Default: ""
+	Fingerprint string `json:"fingerprint"`
+
+	// FingerprintMaxAge defines how long (in seconds) fingerprinted files
+	// can be cached by clients.
+	//
+	// Optional. Default: 31536000 (1 year)
+	FingerprintMaxAge int `json:"fingerprint_max_age"`
+}
+
+// FingerprintPattern matches the names of files, which contain a hash of at
+// least 8 hex digits before the file extension, e.g. "app.3f2a9c1b.js".
+// It can be used as the Fingerprint of the StaticConfig.
+const FingerprintPattern = `[.-][0-9a-fA-F]{8,}\.[^.]+$`
+
+// DefaultStaticConfig contains the default value for
+// the configuration of serving static files
+var DefaultStaticConfig = &StaticConfig{
+	Prefix:            "",
+	Index:             "index.html",
+	Browse:            false,
+	Hidden:            false,
+	Fallback:          "",
+	Precompressed:     false,
+	Fingerprint:       "",
+	FingerprintMaxAge: 31536000,
+}
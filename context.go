@@ -2,6 +2,7 @@
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,8 +21,34 @@ type Context struct {
 	Request  *http.Request       `json:"-"`
 	Response http.ResponseWriter `json:"-"`
 	Params   url.Values          `json:"params"`
+
+	values map[string]any
 }
 
+// Keys of the values which are stored on the Context by the
+// middlewares provided by Lungo.
+const (
+	// NonceKey is the key of the Content-Security-Policy nonce
+	// generated for the current request.
+	NonceKey = "lungo.nonce"
+
+	// RendererKey is the key of the Renderer used by `c.Render()`.
+	RendererKey = "lungo.renderer"
+
+	// RequestIDKey is the key of the ID of the current request.
+	RequestIDKey = "lungo.request_id"
+
+	// PrincipalKey is the key of the authenticated principal,
+	// e.g. a user or client, of the current request.
+	PrincipalKey = "lungo.principal"
+
+	// CSRFTokenKey is the key of the CSRF token of the current request.
+	CSRFTokenKey = "lungo.csrf_token"
+
+	// SessionKey is the key of the session of the current request.
+	SessionKey = "lungo.session"
+)
+
 // Reset applies the given request to the Context instance.
 //
 // The `App` property is assumed to be static, thus it will not be changed.
@@ -31,6 +58,57 @@ func (c *Context) Reset(w http.ResponseWriter, r *http.Request) {
 	c.Request = r
 	c.Response = w
 	c.Params = params
+	c.values = nil
+}
+
+// Copy returns a copy of the Context, which can safely be used
+// outside of the current request, e.g. by another goroutine.
+//
+// The parameters and values of the copy are independent
+// of the original Context.
+func (c *Context) Copy() *Context {
+	cc := &Context{
+		App:      c.App,
+		Request:  c.Request,
+		Response: c.Response,
+		Params:   make(url.Values, len(c.Params)),
+	}
+	for k, v := range c.Params {
+		cc.Params[k] = append([]string(nil), v...)
+	}
+	if c.values != nil {
+		cc.values = make(map[string]any, len(c.values))
+		for k, v := range c.values {
+			cc.values[k] = v
+		}
+	}
+	return cc
+}
+
+// Get returns the value stored on the Context for the given key
+// or nil if there is no value associated with the key.
+func (c *Context) Get(key string) any {
+	return c.values[key]
+}
+
+// Set stores the value on the Context for the given key.
+// It replaces any existing value associated with the key.
+// Values are only kept for the duration of the current request.
+func (c *Context) Set(key string, value any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[key] = value
+}
+
+// Nonce returns the Content-Security-Policy nonce generated for the
+// current request or the empty string if no nonce was generated.
+//
+// The nonce is intended to be rendered into the `nonce` attribute of
+// inline script and style elements.
+func (c *Context) Nonce() string {
+	nonce, _ := c.Get(NonceKey).(string)
+	return nonce
 }
 
 // Flush implements the http.Flusher interface to allow an HTTP handler to flush
@@ -242,6 +320,65 @@ func (c *Context) Json(code int, value any) (err error) {
 	return
 }
 
+// CSRFToken returns the CSRF token of the current request or the
+// empty string if no token was generated for the request.
+//
+// The token is generated by the csrf middleware and is intended to be
+// rendered into forms or sent inside of the `X-CSRF-Token` header.
+// The middleware may store a function, which issues a new token on
+// demand, thus this method must be called before the response is written.
+func (c *Context) CSRFToken() string {
+	switch token := c.Get(CSRFTokenKey).(type) {
+	case string:
+		return token
+	case func(*Context) string:
+		return token(c)
+	}
+	return ""
+}
+
+// RequestID returns the ID of the current request or the
+// empty string if no ID was assigned to the request.
+//
+// The ID is usually assigned by the requestid middleware.
+func (c *Context) RequestID() string {
+	id, _ := c.Get(RequestIDKey).(string)
+	return id
+}
+
+// Principal returns the authenticated principal of the current
+// request or nil if the request was not authenticated.
+//
+// The principal is usually assigned by the auth middleware.
+func (c *Context) Principal() any {
+	return c.Get(PrincipalKey)
+}
+
+// Render dispatches a HTML response by rendering the template with the given name.
+// Use the method parameter `code` to set the header status code.
+// Use the method parameter `data` to supply the data passed to the template.
+//
+// The template is rendered by the Renderer stored on the Context,
+// which is usually provided by the template middleware.
+func (c *Context) Render(code int, name string, data any) (err error) {
+	r, ok := c.Get(RendererKey).(Renderer)
+	if !ok {
+		return ErrRendererNotFound
+	}
+
+	// Render into a buffer first, such that a failing template
+	// does not result in a partially written response.
+	var b bytes.Buffer
+	if err = r.Render(&b, name, data); err != nil {
+		return
+	}
+
+	c.SetHeader(HeaderContentType, MIMETextHTMLCharsetUTF8)
+	c.WriteHeader(code)
+	_, err = b.WriteTo(c.Response)
+	return
+}
+
 // DecodeJSONBody decodes an object with a given interface from a JSON request body.
 func (c *Context) DecodeJSONBody(dst any) error {
 
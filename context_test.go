@@ -3,6 +3,8 @@
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -119,6 +121,75 @@ func TestContextParam(t *testing.T) {
 	assertEqual(t, "bar", c.ParamOrDefault("name", "bar"))
 }
 
+func TestContextValues(t *testing.T) {
+	app := New()
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := app.NewContext(rr, req)
+
+	assertNil(t, c.Get("foo"))
+	assertEqual(t, "", c.Nonce())
+
+	c.Set("foo", "bar")
+	assertEqual(t, "bar", c.Get("foo"))
+
+	c.Set(NonceKey, "abc")
+	assertEqual(t, "abc", c.Nonce())
+
+	assertEqual(t, "", c.RequestID())
+	c.Set(RequestIDKey, "123")
+	assertEqual(t, "123", c.RequestID())
+
+	assertNil(t, c.Principal())
+	c.Set(PrincipalKey, "user")
+	assertEqual(t, "user", c.Principal())
+
+	assertEqual(t, "", c.CSRFToken())
+	c.Set(CSRFTokenKey, "token")
+	assertEqual(t, "token", c.CSRFToken())
+	c.Set(CSRFTokenKey, func(c *Context) string { return "issued" })
+	assertEqual(t, "issued", c.CSRFToken())
+
+	c.Reset(rr, req)
+	assertNil(t, c.Get("foo"))
+	assertEqual(t, "", c.Nonce())
+	assertEqual(t, "", c.RequestID())
+	assertNil(t, c.Principal())
+	assertEqual(t, "", c.CSRFToken())
+}
+
+func TestContextCopy(t *testing.T) {
+	app := New()
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/?foo=bar", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := app.NewContext(rr, req)
+	c.Set("foo", "bar")
+
+	cc := c.Copy()
+	assertEqual(t, app, cc.App)
+	assertEqual(t, req, cc.Request)
+	assertEqual(t, "bar", cc.Param("foo"))
+	assertEqual(t, "bar", cc.Get("foo"))
+
+	cc.SetParam("foo", "baz")
+	cc.Set("foo", "baz")
+	assertEqual(t, "bar", c.Param("foo"))
+	assertEqual(t, "bar", c.Get("foo"))
+
+	c.Reset(rr, req)
+	assertEqual(t, "baz", cc.Get("foo"))
+}
+
 func TestContextHeader(t *testing.T) {
 	app := New()
 
@@ -281,6 +352,44 @@ func TestContextJson(t *testing.T) {
 	assertEqual(t, "{\"message\":\"Hello, world!\"}", strings.TrimSpace(rr.Body.String()))
 }
 
+type testRenderer struct{}
+
+// Implement `Render` method of Renderer interface
+func (r *testRenderer) Render(w io.Writer, name string, data any) error {
+	if name != "index" {
+		return errors.New("Render: template is undefined")
+	}
+	_, err := fmt.Fprintf(w, "<p>%v</p>", data)
+	return err
+}
+
+func TestContextRender(t *testing.T) {
+	app := New()
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := app.NewContext(rr, req)
+
+	err = c.Render(http.StatusOK, "index", "Hello, world!")
+	assertEqual(t, ErrRendererNotFound, err)
+
+	c.Set(RendererKey, &testRenderer{})
+
+	err = c.Render(http.StatusOK, "missing", nil)
+	assertNotNil(t, err)
+	assertEqual(t, "", rr.Body.String())
+
+	err = c.Render(http.StatusOK, "index", "Hello, world!")
+	assertNil(t, err)
+	assertEqual(t, http.StatusOK, rr.Code)
+	assertEqual(t, MIMETextHTMLCharsetUTF8, rr.Header().Get(HeaderContentType))
+	assertEqual(t, "<p>Hello, world!</p>", rr.Body.String())
+}
+
 func TestContextDecodeJSONBody(t *testing.T) {
 	var tests = []struct {
 		body      io.Reader
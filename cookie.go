@@ -0,0 +1,209 @@
+package lungo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCookieKeysMissing is returned if no keys are configured
+	// to sign or encrypt cookies, see `Config.CookieKeys`.
+	ErrCookieKeysMissing = errors.New("Cookie: no cookie keys configured")
+
+	// ErrCookieInvalid is returned if the value of a signed or
+	// encrypted cookie is malformed or has been tampered with.
+	ErrCookieInvalid = errors.New("Cookie: value is invalid")
+
+	// ErrCookieExpired is returned if a signed or encrypted cookie has expired.
+	ErrCookieExpired = errors.New("Cookie: value has expired")
+)
+
+// SetSignedCookie adds a Set-Cookie header with a signed cookie to the
+// response. The value of the cookie is readable by the client, but can
+// not be modified without invalidating the signature. The expiry of the
+// cookie, as defined by its MaxAge or Expires attribute, is signed along
+// with the value.
+func (c *Context) SetSignedCookie(cookie *http.Cookie) error {
+	keys := c.App.config.CookieKeys
+	if len(keys) == 0 {
+		return ErrCookieKeysMissing
+	}
+
+	payload := encodeCookiePayload(cookie)
+	sig := signCookie(keys[0], cookie.Name, payload)
+
+	signed := *cookie
+	signed.Value = base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sig)
+	c.SetCookie(&signed)
+	return nil
+}
+
+// SignedCookie returns the named signed cookie with the verified value.
+// It returns http.ErrNoCookie if the cookie is not found, ErrCookieInvalid
+// if the signature is invalid and ErrCookieExpired if the cookie has expired.
+func (c *Context) SignedCookie(name string) (*http.Cookie, error) {
+	keys := c.App.config.CookieKeys
+	if len(keys) == 0 {
+		return nil, ErrCookieKeysMissing
+	}
+
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p, s, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return nil, ErrCookieInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(p)
+	if err != nil {
+		return nil, ErrCookieInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrCookieInvalid
+	}
+
+	for _, key := range keys {
+		if hmac.Equal(sig, signCookie(key, name, payload)) {
+			return decodeCookiePayload(cookie, payload)
+		}
+	}
+	return nil, ErrCookieInvalid
+}
+
+// SetEncryptedCookie adds a Set-Cookie header with an encrypted cookie to
+// the response. The value of the cookie is encrypted and authenticated using
+// AES-256-GCM, thus it can neither be read nor modified by the client.
+// The expiry of the cookie, as defined by its MaxAge or Expires attribute,
+// is encrypted along with the value.
+func (c *Context) SetEncryptedCookie(cookie *http.Cookie) error {
+	keys := c.App.config.CookieKeys
+	if len(keys) == 0 {
+		return ErrCookieKeysMissing
+	}
+
+	aead, err := cookieCipher(keys[0])
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	encrypted := *cookie
+	encrypted.Value = base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, encodeCookiePayload(cookie), []byte(cookie.Name)))
+	c.SetCookie(&encrypted)
+	return nil
+}
+
+// EncryptedCookie returns the named encrypted cookie with the decrypted value.
+// It returns http.ErrNoCookie if the cookie is not found, ErrCookieInvalid
+// if the value can not be decrypted and ErrCookieExpired if the cookie has
+// expired.
+func (c *Context) EncryptedCookie(name string) (*http.Cookie, error) {
+	keys := c.App.config.CookieKeys
+	if len(keys) == 0 {
+		return nil, ErrCookieKeysMissing
+	}
+
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, ErrCookieInvalid
+	}
+
+	for _, key := range keys {
+		aead, err := cookieCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < aead.NonceSize() {
+			return nil, ErrCookieInvalid
+		}
+
+		nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+		if payload, err := aead.Open(nil, nonce, ciphertext, []byte(name)); err == nil {
+			return decodeCookiePayload(cookie, payload)
+		}
+	}
+	return nil, ErrCookieInvalid
+}
+
+// encodeCookiePayload encodes the expiry of the cookie as
+// Unix timestamp followed by the value of the cookie.
+// An expiry of zero denotes a cookie without expiry.
+func encodeCookiePayload(cookie *http.Cookie) []byte {
+	var expires int64
+	switch {
+	case cookie.MaxAge > 0:
+		expires = time.Now().Add(time.Duration(cookie.MaxAge) * time.Second).Unix()
+	case !cookie.Expires.IsZero():
+		expires = cookie.Expires.Unix()
+	}
+
+	payload := make([]byte, 8, 8+len(cookie.Value))
+	binary.BigEndian.PutUint64(payload, uint64(expires))
+	return append(payload, cookie.Value...)
+}
+
+// decodeCookiePayload returns a copy of the cookie with the value
+// of the payload, if the payload has not expired.
+func decodeCookiePayload(cookie *http.Cookie, payload []byte) (*http.Cookie, error) {
+	if len(payload) < 8 {
+		return nil, ErrCookieInvalid
+	}
+
+	expires := int64(binary.BigEndian.Uint64(payload))
+	if expires != 0 && time.Now().Unix() >= expires {
+		return nil, ErrCookieExpired
+	}
+
+	decoded := *cookie
+	decoded.Value = string(payload[8:])
+	return &decoded, nil
+}
+
+// signCookie returns the signature of the payload of the named cookie.
+// The name is signed as well, such that the value can not be used for
+// other cookies.
+func signCookie(key []byte, name string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, deriveCookieKey(key, "signing"))
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// cookieCipher returns the AES-256-GCM cipher derived from the key.
+func cookieCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveCookieKey(key, "encryption"))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveCookieKey derives a 256 bit key for the given purpose from the key,
+// such that the same key can be used for signing and encryption.
+func deriveCookieKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("lungo.cookie." + purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,164 @@
+package lungo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTrip sets a cookie using the set function and returns
+// a Context for a request, which contains the resulting cookie.
+func roundTrip(t *testing.T, app *App, set func(c *Context) error) *Context {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := set(app.NewContext(rr, req)); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err = http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cookie := range rr.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	return app.NewContext(httptest.NewRecorder(), req)
+}
+
+func TestContextSignedCookie(t *testing.T) {
+	app := New(func(c *Config) {
+		c.CookieKeys = [][]byte{[]byte("secret")}
+	})
+
+	c := roundTrip(t, app, func(c *Context) error {
+		return c.SetSignedCookie(&http.Cookie{Name: "foo", Value: "bar baz", MaxAge: 60})
+	})
+
+	cookie, err := c.SignedCookie("foo")
+	assertNil(t, err)
+	assertEqual(t, "bar baz", cookie.Value)
+
+	raw, err := c.Cookie("foo")
+	assertNil(t, err)
+	assertEqual(t, false, raw.Value == "bar baz")
+
+	_, err = c.SignedCookie("missing")
+	assertEqual(t, http.ErrNoCookie, err)
+
+	// The signature is bound to the name of the cookie.
+	c.Request.AddCookie(&http.Cookie{Name: "other", Value: raw.Value})
+	_, err = c.SignedCookie("other")
+	assertEqual(t, ErrCookieInvalid, err)
+
+	for _, value := range []string{"invalid", "!.!", "YWJj.!", raw.Value[1:]} {
+		c.Request.Header.Del(HeaderCookie)
+		c.Request.AddCookie(&http.Cookie{Name: "foo", Value: value})
+		_, err = c.SignedCookie("foo")
+		assertEqual(t, ErrCookieInvalid, err)
+	}
+
+	// Rotated keys are still accepted, while removed keys are not.
+	c.Request.Header.Del(HeaderCookie)
+	c.Request.AddCookie(raw)
+	app.Config().CookieKeys = [][]byte{[]byte("new"), []byte("secret")}
+	cookie, err = c.SignedCookie("foo")
+	assertNil(t, err)
+	assertEqual(t, "bar baz", cookie.Value)
+
+	app.Config().CookieKeys = [][]byte{[]byte("new")}
+	_, err = c.SignedCookie("foo")
+	assertEqual(t, ErrCookieInvalid, err)
+}
+
+func TestContextEncryptedCookie(t *testing.T) {
+	app := New(func(c *Config) {
+		c.CookieKeys = [][]byte{[]byte("secret")}
+	})
+
+	c := roundTrip(t, app, func(c *Context) error {
+		return c.SetEncryptedCookie(&http.Cookie{Name: "foo", Value: "bar baz"})
+	})
+
+	cookie, err := c.EncryptedCookie("foo")
+	assertNil(t, err)
+	assertEqual(t, "bar baz", cookie.Value)
+
+	raw, err := c.Cookie("foo")
+	assertNil(t, err)
+
+	_, err = c.EncryptedCookie("missing")
+	assertEqual(t, http.ErrNoCookie, err)
+
+	// Signed cookies can not be decoded as encrypted cookies and vice versa.
+	_, err = c.SignedCookie("foo")
+	assertEqual(t, ErrCookieInvalid, err)
+
+	c.Request.AddCookie(&http.Cookie{Name: "other", Value: raw.Value})
+	_, err = c.EncryptedCookie("other")
+	assertEqual(t, ErrCookieInvalid, err)
+
+	for _, value := range []string{"!", "YWJj", raw.Value[:len(raw.Value)-2]} {
+		c.Request.Header.Del(HeaderCookie)
+		c.Request.AddCookie(&http.Cookie{Name: "foo", Value: value})
+		_, err = c.EncryptedCookie("foo")
+		assertEqual(t, ErrCookieInvalid, err)
+	}
+
+	c.Request.Header.Del(HeaderCookie)
+	c.Request.AddCookie(raw)
+	app.Config().CookieKeys = [][]byte{[]byte("new"), []byte("secret")}
+	cookie, err = c.EncryptedCookie("foo")
+	assertNil(t, err)
+	assertEqual(t, "bar baz", cookie.Value)
+}
+
+func TestContextCookieExpired(t *testing.T) {
+	app := New(func(c *Config) {
+		c.CookieKeys = [][]byte{[]byte("secret")}
+	})
+
+	expires := time.Now().Add(-time.Minute)
+	c := roundTrip(t, app, func(c *Context) error {
+		if err := c.SetSignedCookie(&http.Cookie{Name: "signed", Value: "foo", Expires: expires}); err != nil {
+			return err
+		}
+		return c.SetEncryptedCookie(&http.Cookie{Name: "encrypted", Value: "foo", Expires: expires})
+	})
+
+	_, err := c.SignedCookie("signed")
+	assertEqual(t, ErrCookieExpired, err)
+	_, err = c.EncryptedCookie("encrypted")
+	assertEqual(t, ErrCookieExpired, err)
+}
+
+func TestContextCookieKeysMissing(t *testing.T) {
+	app := New()
+	c := app.NewContext(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	assertEqual(t, ErrCookieKeysMissing, c.SetSignedCookie(&http.Cookie{Name: "foo"}))
+	assertEqual(t, ErrCookieKeysMissing, c.SetEncryptedCookie(&http.Cookie{Name: "foo"}))
+
+	_, err := c.SignedCookie("foo")
+	assertEqual(t, ErrCookieKeysMissing, err)
+	_, err = c.EncryptedCookie("foo")
+	assertEqual(t, ErrCookieKeysMissing, err)
+}
+
+func TestConfigCookieKeysJSON(t *testing.T) {
+	config := &Config{CookieKeys: [][]byte{[]byte("secret")}}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, false, strings.Contains(string(b), "cookie_keys"))
+	assertEqual(t, false, strings.Contains(string(b), base64.StdEncoding.EncodeToString([]byte("secret"))))
+}
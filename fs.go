@@ -1,26 +1,281 @@
 package lungo
 
 import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 type fileServer struct {
-	root http.Dir
+	root        fs.FS
+	config      *StaticConfig
+	fingerprint *regexp.Regexp
+}
+
+// encodings defines the content codings of precompressed
+// files and their extension in order of preference.
+var encodings = []struct {
+	coding    string
+	extension string
+}{
+	{coding: "br", extension: ".br"},
+	{coding: "gzip", extension: ".gz"},
 }
 
 // ServeHTTP implements the Handler interface for the file server.
 func (f *fileServer) ServeHTTP(c *Context) error {
-	fp := path.Join(string(f.root), c.Request.URL.Path)
-	if _, err := os.Stat(fp); err != nil {
+	p := c.Request.URL.Path
+	if prefix := strings.TrimSuffix(f.config.Prefix, "/"); prefix != "" {
+		p = strings.TrimPrefix(p, prefix)
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if name == "" {
+		name = "."
+	}
+
+	if !f.config.Hidden && isHidden(name) {
+		return c.NotFound()
+	}
+
+	file, err := f.root.Open(name)
+	if err != nil {
+		return f.fallback(c)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return f.fallback(c)
+	}
+
+	if !info.IsDir() {
+		return f.serve(c, name, info, file)
+	}
+
+	// Redirect to the canonical path of the directory, such that relative
+	// links are resolved correctly. The redirect is relative, since a
+	// prefix of the path may have been stripped, e.g. by `App.Mount()`.
+	if !strings.HasSuffix(c.Request.URL.Path, "/") {
+		target := path.Base(c.Request.URL.Path) + "/"
+		if q := c.Request.URL.RawQuery; q != "" {
+			target += "?" + q
+		}
+		c.SetHeader(HeaderLocation, target)
+		c.WriteHeader(http.StatusMovedPermanently)
+		return nil
+	}
+
+	if f.config.Index != "" {
+		index := path.Join(name, f.config.Index)
+		if file, info, err := f.open(index); err == nil {
+			defer file.Close()
+			return f.serve(c, index, info, file)
+		}
+	}
+
+	if f.config.Browse {
+		return f.browse(c, name)
+	}
+
+	return f.fallback(c)
+}
+
+// serve replies to the request with the named file. It sets the `Cache-Control`
+// header for fingerprinted files and serves a precompressed variant of the
+// file, if enabled and accepted by the client.
+func (f *fileServer) serve(c *Context, name string, info fs.FileInfo, file fs.File) error {
+	if f.fingerprint != nil && f.fingerprint.MatchString(info.Name()) {
+		c.SetHeader(HeaderCacheControl, fmt.Sprintf("public, max-age=%d, immutable", f.config.FingerprintMaxAge))
+	}
+
+	if !f.config.Precompressed {
+		return serveContent(c, info.Name(), info, file)
+	}
+
+	c.AddHeader(HeaderVary, HeaderAcceptEncoding)
+
+	accept := c.Header(HeaderAcceptEncoding)
+	for _, e := range encodings {
+		if !acceptsEncoding(accept, e.coding) {
+			continue
+		}
+
+		variant, vinfo, err := f.open(name + e.extension)
+		if err != nil {
+			continue
+		}
+		defer variant.Close()
+
+		// Set the Content-Type of the original file, since it
+		// can not be detected from the compressed content.
+		ct := mime.TypeByExtension(path.Ext(name))
+		if ct == "" {
+			ct = MIMEOctetStream
+		}
+		c.SetHeader(HeaderContentType, ct)
+		c.SetHeader(HeaderContentEncoding, e.coding)
+
+		return serveContent(c, info.Name(), vinfo, variant)
+	}
+
+	return serveContent(c, info.Name(), info, file)
+}
+
+// open opens the named file, which must not be a directory.
+func (f *fileServer) open(name string) (fs.File, fs.FileInfo, error) {
+	file, err := f.root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if info.IsDir() {
+		file.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+
+	return file, info, nil
+}
+
+// fallback serves the fallback file or replies
+// with an HTTP 404 not found error if unset.
+func (f *fileServer) fallback(c *Context) error {
+	if f.config.Fallback == "" {
+		return c.NotFound()
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+f.config.Fallback), "/")
+	file, info, err := f.open(name)
+	if err != nil {
 		return c.NotFound()
 	}
+	defer file.Close()
+
+	return f.serve(c, name, info, file)
+}
+
+// browse serves a listing of the contents of the named directory.
+func (f *fileServer) browse(c *Context, name string) error {
+	entries, err := fs.ReadDir(f.root, name)
+	if err != nil {
+		return c.NotFound()
+	}
+
+	var b strings.Builder
+	b.WriteString("<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<pre>\n")
+	for _, e := range entries {
+		n := e.Name()
+		if !f.config.Hidden && strings.HasPrefix(n, ".") {
+			continue
+		}
+		if e.IsDir() {
+			n += "/"
+		}
+		// The name may contain a colon, which would be interpreted
+		// as the scheme of the URL, thus the path is escaped.
+		u := url.URL{Path: n}
+		fmt.Fprintf(&b, "<a href=\"%s\">%s</a>\n", html.EscapeString(u.String()), html.EscapeString(n))
+	}
+	b.WriteString("</pre>\n")
+
+	c.SetHeader(HeaderContentType, MIMETextHTMLCharsetUTF8)
+	c.WriteHeader(http.StatusOK)
+	_, err = io.WriteString(c.Response, b.String())
+	return err
+}
+
+// isHidden reports whether any element of the
+// slash-separated path name starts with a dot.
+func isHidden(name string) bool {
+	for _, e := range strings.Split(name, "/") {
+		if e != "." && strings.HasPrefix(e, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// serveContent replies to the request using the content of the file.
+// It handles Range requests as well as the If-Match, If-Unmodified-Since,
+// If-None-Match, If-Modified-Since and If-Range preconditions.
+func serveContent(c *Context, name string, info fs.FileInfo, file fs.File) error {
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		// Files which do not support seeking are read into memory.
+		b, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(b)
+	}
+
+	http.ServeContent(c.Response, c.Request, name, info.ModTime(), content)
+	return nil
+}
+
+// acceptsEncoding reports whether the content coding is acceptable
+// according to the value of an `Accept-Encoding` header.
+func acceptsEncoding(accept, coding string) bool {
+	acceptable := false
+	for _, s := range strings.Split(accept, ",") {
+		token, params, _ := strings.Cut(s, ";")
+		token = strings.ToLower(strings.TrimSpace(token))
+		if token != coding && token != "*" {
+			continue
+		}
 
-	return c.File(fp)
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+
+		// An explicit entry of the coding takes precedence over "*".
+		if token == coding {
+			return q > 0
+		}
+		acceptable = q > 0
+	}
+	return acceptable
 }
 
 // FileHandler creates a new Handler that serves static files in a directory.
-func FileHandler(root string) Handler {
-	return &fileServer{root: http.Dir(root)}
+func FileHandler(root string, configure ...func(*StaticConfig)) Handler {
+	return FileSystemHandler(os.DirFS(root), configure...)
+}
+
+// FileSystemHandler creates a new Handler that serves static files
+// from the file system, e.g. an embed.FS.
+// It panics if the Fingerprint of the configuration is not a valid regular expression.
+func FileSystemHandler(fsys fs.FS, configure ...func(*StaticConfig)) Handler {
+	config := new(StaticConfig)
+	*config = *DefaultStaticConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	var fingerprint *regexp.Regexp
+	if config.Fingerprint != "" {
+		fingerprint = regexp.MustCompile(config.Fingerprint)
+	}
+
+	return &fileServer{root: fsys, config: config, fingerprint: fingerprint}
 }
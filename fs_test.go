@@ -1,10 +1,14 @@
 package lungo
 
 import (
+	"mime"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"reflect"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestFileServer(t *testing.T) {
@@ -53,3 +57,214 @@ func TestFileServer(t *testing.T) {
 		}
 	}
 }
+
+func TestFileSystemHandler(t *testing.T) {
+	modtime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"index.html":     {Data: []byte("<h1>Hello, world!</h1>"), ModTime: modtime},
+		"css/style.css":  {Data: []byte("body{}"), ModTime: modtime},
+		"img/.gitignore": {Data: []byte("")},
+	}
+
+	var tests = []struct {
+		path   string
+		header map[string]string
+		status int
+		body   string
+	}{
+		{path: "/index.html", status: http.StatusOK, body: "<h1>Hello, world!</h1>"},
+		{path: "/css/style.css", status: http.StatusOK, body: "body{}"},
+		{path: "/css/../index.html", status: http.StatusOK, body: "<h1>Hello, world!</h1>"},
+		{path: "/missing.html", status: http.StatusNotFound},
+		{path: "/css", status: http.StatusMovedPermanently},
+		{path: "/css/", status: http.StatusNotFound},
+		{path: "/img/.gitignore", status: http.StatusNotFound},
+		{path: "/index.html", header: map[string]string{HeaderRange: "bytes=4-8"}, status: http.StatusPartialContent, body: "Hello"},
+		{path: "/index.html", header: map[string]string{HeaderIfModifiedSince: modtime.Format(http.TimeFormat)}, status: http.StatusNotModified},
+		{path: "/index.html", header: map[string]string{HeaderIfModifiedSince: modtime.Add(-time.Hour).Format(http.TimeFormat)}, status: http.StatusOK, body: "<h1>Hello, world!</h1>"},
+	}
+
+	app := New()
+
+	for _, testcase := range tests {
+		req, err := http.NewRequest("GET", testcase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
+		rr := httptest.NewRecorder()
+		c := app.NewContext(rr, req)
+
+		err = FileSystemHandler(fsys).ServeHTTP(c)
+		if re, ok := err.(*RequestError); ok {
+			rr.Code = re.Code
+		}
+
+		assertEqual(t, testcase.status, rr.Code)
+		if testcase.body != "" {
+			assertEqual(t, testcase.body, rr.Body.String())
+		}
+	}
+}
+
+func TestFileSystemHandlerConfig(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":         {Data: []byte("index")},
+		"docs/index.htm":     {Data: []byte("docs")},
+		"docs/a:b.txt":       {Data: []byte("a:b")},
+		"docs/.secret":       {Data: []byte("secret")},
+		"docs/guide/01.html": {Data: []byte("guide")},
+		".env":               {Data: []byte("env")},
+	}
+
+	var tests = []struct {
+		path      string
+		configure func(*StaticConfig)
+		status    int
+		body      string
+	}{
+		{path: "/assets/index.html", configure: func(c *StaticConfig) { c.Prefix = "/assets" }, status: http.StatusOK, body: "index"},
+		{path: "/assets/", configure: func(c *StaticConfig) { c.Prefix = "/assets/" }, status: http.StatusOK, body: "index"},
+		{path: "/assets", configure: func(c *StaticConfig) { c.Prefix = "/assets" }, status: http.StatusMovedPermanently},
+		{path: "/", status: http.StatusOK, body: "index"},
+		{path: "/", configure: func(c *StaticConfig) { c.Index = "" }, status: http.StatusNotFound},
+		{path: "/docs/", status: http.StatusNotFound},
+		{path: "/docs/", configure: func(c *StaticConfig) { c.Index = "index.htm" }, status: http.StatusOK, body: "docs"},
+		{path: "/docs/", configure: func(c *StaticConfig) { c.Browse = true }, status: http.StatusOK, body: "<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<pre>\n<a href=\"./a:b.txt\">a:b.txt</a>\n<a href=\"guide/\">guide/</a>\n<a href=\"index.htm\">index.htm</a>\n</pre>\n"},
+		{path: "/.env", status: http.StatusNotFound},
+		{path: "/docs/.secret", status: http.StatusNotFound},
+		{path: "/.env", configure: func(c *StaticConfig) { c.Hidden = true }, status: http.StatusOK, body: "env"},
+		{path: "/users/1", status: http.StatusNotFound},
+		{path: "/users/1", configure: func(c *StaticConfig) { c.Fallback = "index.html" }, status: http.StatusOK, body: "index"},
+		{path: "/docs/guide/", configure: func(c *StaticConfig) { c.Fallback = "/index.html" }, status: http.StatusOK, body: "index"},
+		{path: "/users/1", configure: func(c *StaticConfig) { c.Fallback = "missing.html" }, status: http.StatusNotFound},
+	}
+
+	app := New()
+
+	for _, testcase := range tests {
+		req, err := http.NewRequest("GET", testcase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		c := app.NewContext(rr, req)
+
+		configure := testcase.configure
+		if configure == nil {
+			configure = func(c *StaticConfig) {}
+		}
+
+		err = FileSystemHandler(fsys, configure).ServeHTTP(c)
+		if re, ok := err.(*RequestError); ok {
+			rr.Code = re.Code
+		}
+
+		assertEqual(t, testcase.status, rr.Code)
+		if testcase.body != "" {
+			assertEqual(t, testcase.body, rr.Body.String())
+		}
+	}
+}
+
+func TestFileSystemHandlerMount(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/index.html": {Data: []byte("docs")},
+	}
+
+	var tests = []struct {
+		path     string
+		status   int
+		location string
+	}{
+		{path: "/static/docs", status: http.StatusMovedPermanently, location: "docs/"},
+		{path: "/static/docs?v=1", status: http.StatusMovedPermanently, location: "docs/?v=1"},
+		{path: "/static/docs/", status: http.StatusOK},
+	}
+
+	group := New()
+	group.StaticFS("/", fsys)
+
+	app := New()
+	app.Mount("/static", group)
+
+	for _, testcase := range tests {
+		req, err := http.NewRequest("GET", testcase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+
+		assertEqual(t, testcase.status, rr.Code)
+		assertEqual(t, testcase.location, rr.Header().Get(HeaderLocation))
+	}
+}
+
+func TestFileSystemHandlerPrecompressed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":             {Data: []byte("plain")},
+		"app.js.gz":          {Data: []byte("gzip")},
+		"app.js.br":          {Data: []byte("brotli")},
+		"style.css":          {Data: []byte("plain")},
+		"style.css.gz":       {Data: []byte("gzip")},
+		"app.3f2a9c1b.js":    {Data: []byte("fingerprinted")},
+		"app.3f2a9c1b.js.br": {Data: []byte("fingerprinted brotli")},
+	}
+
+	var tests = []struct {
+		path          string
+		accept        string
+		precompressed bool
+		body          string
+		encoding      string
+		cacheControl  string
+		fingerprint   string
+	}{
+		{path: "/app.js", accept: "gzip, br", body: "plain"},
+		{path: "/app.js", accept: "", precompressed: true, body: "plain"},
+		{path: "/app.js", accept: "gzip, br", precompressed: true, body: "brotli", encoding: "br"},
+		{path: "/app.js", accept: "gzip, br;q=0", precompressed: true, body: "gzip", encoding: "gzip"},
+		{path: "/app.js", accept: "*", precompressed: true, body: "brotli", encoding: "br"},
+		{path: "/app.js", accept: "*;q=0, gzip", precompressed: true, body: "gzip", encoding: "gzip"},
+		{path: "/style.css", accept: "br", precompressed: true, body: "plain"},
+		{path: "/style.css", accept: "GZIP", precompressed: true, body: "gzip", encoding: "gzip"},
+		{path: "/app.3f2a9c1b.js", body: "fingerprinted"},
+		{path: "/app.3f2a9c1b.js", fingerprint: FingerprintPattern, body: "fingerprinted", cacheControl: "public, max-age=31536000, immutable"},
+		{path: "/app.3f2a9c1b.js", fingerprint: FingerprintPattern, accept: "br", precompressed: true, body: "fingerprinted brotli", encoding: "br", cacheControl: "public, max-age=31536000, immutable"},
+	}
+
+	app := New()
+
+	for _, testcase := range tests {
+		req, err := http.NewRequest("GET", testcase.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(HeaderAcceptEncoding, testcase.accept)
+
+		rr := httptest.NewRecorder()
+		c := app.NewContext(rr, req)
+
+		h := FileSystemHandler(fsys, func(c *StaticConfig) {
+			c.Precompressed = testcase.precompressed
+			c.Fingerprint = testcase.fingerprint
+		})
+		assertNil(t, h.ServeHTTP(c))
+
+		assertEqual(t, http.StatusOK, rr.Code)
+		assertEqual(t, testcase.body, rr.Body.String())
+		assertEqual(t, testcase.encoding, rr.Header().Get(HeaderContentEncoding))
+		assertEqual(t, testcase.cacheControl, rr.Header().Get(HeaderCacheControl))
+		if testcase.precompressed {
+			assertEqual(t, HeaderAcceptEncoding, rr.Header().Get(HeaderVary))
+			assertEqual(t, mime.TypeByExtension(path.Ext(testcase.path)), rr.Header().Get(HeaderContentType))
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package lungo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// hooks stores the lifecycle hooks of an App.
+type hooks struct {
+	// started reports whether the start hooks were executed
+	// successfully since the application was last shut down.
+	started    bool
+	startMutex sync.Mutex
+
+	onStart    []func() error
+	onListen   []func(addr string) error
+	onRoute    []func(route Route) error
+	onShutdown []func(ctx context.Context) error
+}
+
+// multiError aggregates the errors of multiple hooks.
+type multiError []error
+
+// `Error` implements the error interface
+func (e multiError) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Is reports whether any of the aggregated errors matches the target,
+// such that errors.Is inspects them on Go versions prior to 1.20.
+func (e multiError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the aggregated errors that matches the target,
+// such that errors.As inspects them on Go versions prior to 1.20.
+func (e multiError) As(target any) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the aggregated errors, which are
+// inspected by errors.Is and errors.As on Go 1.20+.
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+// joinErrors returns an error that aggregates the non-nil errors.
+// It returns nil if there are none and the error itself if there is one.
+func joinErrors(errs ...error) error {
+	var m multiError
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case multiError:
+			m = append(m, e...)
+		default:
+			m = append(m, e)
+		}
+	}
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	}
+	return m
+}
+
+// OnStart registers hooks, which are executed in the order they were
+// registered before the server starts listening, e.g. to warm up caches.
+// The hooks are executed once, even if the application is served on multiple
+// listeners, until the application is shut down.
+// The first hook returning an error aborts the start of the server and
+// the error is returned by `Serve()`, `Listen()`, `Run()` and alike.
+func (app *App) OnStart(hooks ...func() error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.hooks.onStart = append(app.hooks.onStart, hooks...)
+}
+
+// OnListen registers hooks, which are executed in the order they were
+// registered once the server is listening, but before it accepts connections,
+// e.g. to register the application with a service discovery.
+// The hooks receive the address of the listener, which includes the actual
+// port if the server listens on port 0. All hooks are executed, even if a
+// hook returns an error. In that case the listener is closed and the errors
+// are returned by `Serve()`, `Listen()`, `Run()` and alike.
+func (app *App) OnListen(hooks ...func(addr string) error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.hooks.onListen = append(app.hooks.onListen, hooks...)
+}
+
+// OnRoute registers hooks, which are executed in the order they were
+// registered whenever a Route is added to the application, e.g. to print
+// the routes. Routes added before the hooks were registered are not passed
+// to them. All hooks are executed, even if a hook returns an error.
+// In that case the registration of the route panics with the errors.
+func (app *App) OnRoute(hooks ...func(route Route) error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.hooks.onRoute = append(app.hooks.onRoute, hooks...)
+}
+
+// OnShutdown registers hooks, which are executed in the order they were
+// registered after the server was shut down, e.g. to close database
+// connections. The context passed to the hooks is the one passed to
+// `Shutdown()`. All hooks are executed, even if a hook returns an error.
+func (app *App) OnShutdown(hooks ...func(ctx context.Context) error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.hooks.onShutdown = append(app.hooks.onShutdown, hooks...)
+}
+
+// runStartHooks executes the start hooks until the first error,
+// unless they were already executed successfully.
+func (app *App) runStartHooks() error {
+	app.hooks.startMutex.Lock()
+	defer app.hooks.startMutex.Unlock()
+
+	if app.hooks.started {
+		return nil
+	}
+
+	app.mutex.RLock()
+	hooks := app.hooks.onStart
+	app.mutex.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			return err
+		}
+	}
+	app.hooks.started = true
+	return nil
+}
+
+// reset resets the hooks, such that the start hooks are executed
+// again the next time the application is served.
+func (h *hooks) reset() {
+	h.startMutex.Lock()
+	defer h.startMutex.Unlock()
+	h.started = false
+}
+
+// runListenHooks executes the listen hooks and aggregates their errors.
+func (app *App) runListenHooks(addr string) error {
+	app.mutex.RLock()
+	hooks := app.hooks.onListen
+	app.mutex.RUnlock()
+
+	errs := make([]error, 0, len(hooks))
+	for _, hook := range hooks {
+		errs = append(errs, hook(addr))
+	}
+	return joinErrors(errs...)
+}
+
+// runRouteHooks executes the route hooks and aggregates their errors.
+func (app *App) runRouteHooks(route Route) error {
+	app.mutex.RLock()
+	hooks := app.hooks.onRoute
+	app.mutex.RUnlock()
+
+	errs := make([]error, 0, len(hooks))
+	for _, hook := range hooks {
+		errs = append(errs, hook(route))
+	}
+	return joinErrors(errs...)
+}
+
+// runShutdownHooks executes the shutdown hooks and aggregates their errors.
+func (app *App) runShutdownHooks(ctx context.Context) error {
+	app.mutex.RLock()
+	hooks := app.hooks.onShutdown
+	app.mutex.RUnlock()
+
+	errs := make([]error, 0, len(hooks))
+	for _, hook := range hooks {
+		errs = append(errs, hook(ctx))
+	}
+	return joinErrors(errs...)
+}
@@ -0,0 +1,209 @@
+package lungo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestJoinErrors(t *testing.T) {
+	errA := errors.New("A")
+	errB := errors.New("B")
+	errC := errors.New("C")
+
+	tests := []struct {
+		name     string
+		errs     []error
+		expected error
+	}{
+		{name: "None", errs: nil, expected: nil},
+		{name: "Nil", errs: []error{nil, nil}, expected: nil},
+		{name: "Single", errs: []error{nil, errA}, expected: errA},
+		{name: "Multiple", errs: []error{errA, nil, errB}, expected: multiError{errA, errB}},
+		{name: "Nested", errs: []error{multiError{errA, errB}, errC}, expected: multiError{errA, errB, errC}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.expected, joinErrors(tt.errs...))
+		})
+	}
+
+	err := joinErrors(errA, errB)
+	assertEqual(t, "A; B", err.Error())
+	assertEqual(t, true, errors.Is(err, errB))
+	assertEqual(t, false, errors.Is(err, errC))
+}
+
+func TestMultiErrorIsAs(t *testing.T) {
+	errA := errors.New("A")
+	errPath := &fs.PathError{Op: "open", Path: "app.sock", Err: fs.ErrNotExist}
+	m := multiError{errA, fmt.Errorf("wrapped: %w", errPath)}
+
+	// The methods are called explicitly, such that the test
+	// covers Go versions, which don't support Unwrap() []error.
+	assertEqual(t, true, m.Is(errA))
+	assertEqual(t, true, m.Is(fs.ErrNotExist))
+	assertEqual(t, false, m.Is(fs.ErrExist))
+
+	var target *fs.PathError
+	assertEqual(t, true, m.As(&target))
+	assertEqual(t, errPath, target)
+
+	var other *net.OpError
+	assertEqual(t, false, m.As(&other))
+	assertEqual(t, true, errors.As(m, &target))
+}
+
+func TestAppOnRoute(t *testing.T) {
+	app := New()
+	app.Get("/before", func(c *Context) error { return nil })
+
+	routes := make([]string, 0)
+	app.OnRoute(func(route Route) error {
+		routes = append(routes, route.Method+" "+route.Path)
+		return nil
+	})
+
+	app.Get("/", func(c *Context) error { return nil })
+	app.Post("/users", func(c *Context) error { return nil })
+	app.Static("/static", ".")
+	assertEqual(t, []string{"GET /", "POST /users", "GET /static/"}, routes)
+
+	app.OnRoute(func(route Route) error {
+		return errors.New("Error")
+	}, func(route Route) error {
+		return errors.New("Other")
+	})
+	defer func() {
+		err, _ := recover().(error)
+		assertEqual(t, "Error; Other", err.Error())
+	}()
+	app.Delete("/users", func(c *Context) error { return nil })
+}
+
+func TestAppOnStartListen(t *testing.T) {
+	app := New()
+
+	calls := make([]string, 0)
+	app.OnStart(func() error {
+		calls = append(calls, "start 1")
+		return nil
+	}, func() error {
+		calls = append(calls, "start 2")
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listening := make(chan struct{})
+	app.OnListen(func(addr string) error {
+		calls = append(calls, "listen "+addr)
+		close(listening)
+		return nil
+	})
+
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Serve(ln)
+	}()
+
+	<-listening
+	assertEqual(t, nil, app.Shutdown(context.Background()))
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+	assertEqual(t, []string{"start 1", "start 2", "listen " + ln.Addr().String()}, calls)
+}
+
+func TestAppOnStartError(t *testing.T) {
+	app := New()
+
+	calls := make([]int, 0)
+	app.OnStart(func() error {
+		calls = append(calls, 1)
+		return errors.New("Error")
+	}, func() error {
+		calls = append(calls, 2)
+		return nil
+	})
+	app.OnListen(func(addr string) error {
+		calls = append(calls, 3)
+		return nil
+	})
+
+	err := app.Listen("127.0.0.1:0")
+	assertEqual(t, "Error", err.Error())
+	assertEqual(t, []int{1}, calls)
+	assertEqual(t, (*http.Server)(nil), app.Server())
+}
+
+func TestAppOnListenError(t *testing.T) {
+	app := New()
+
+	calls := make([]int, 0)
+	app.OnListen(func(addr string) error {
+		calls = append(calls, 1)
+		return errors.New("Error")
+	}, func(addr string) error {
+		calls = append(calls, 2)
+		return errors.New("Other")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Serve(ln)
+	assertEqual(t, "Error; Other", err.Error())
+	assertEqual(t, []int{1, 2}, calls)
+
+	// The listener is closed if any of the hooks fails.
+	_, err = ln.Accept()
+	assertEqual(t, true, errors.Is(err, net.ErrClosed))
+}
+
+func TestAppOnListenErrorRetry(t *testing.T) {
+	app := New()
+
+	starts := 0
+	app.OnStart(func() error {
+		starts++
+		return nil
+	})
+
+	fail := true
+	listening := make(chan struct{})
+	app.OnListen(func(addr string) error {
+		if fail {
+			return errors.New("Error")
+		}
+		close(listening)
+		return nil
+	})
+
+	err := app.Listen("127.0.0.1:0")
+	assertEqual(t, "Error", err.Error())
+	assertEqual(t, 1, starts)
+	assertEqual(t, (*http.Server)(nil), app.Server())
+	assertEqual(t, 0, len(app.Servers()))
+
+	// The start hooks are executed again, when the start is retried.
+	fail = false
+	cerr := make(chan error)
+	go func() {
+		cerr <- app.Listen("127.0.0.1:0")
+	}()
+
+	<-listening
+	assertEqual(t, 2, starts)
+	assertEqual(t, 1, len(app.Servers()))
+	assertEqual(t, nil, app.Shutdown(context.Background()))
+	assertEqual(t, http.ErrServerClosed, <-cerr)
+}
@@ -0,0 +1,85 @@
+package lungo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listenFdsStart is the first file descriptor passed
+// to the process by the socket activation of systemd.
+const listenFdsStart = 3
+
+var (
+	// ErrSystemdListenerNotFound is returned when the named file descriptor
+	// was not passed to the process by the socket activation of systemd.
+	ErrSystemdListenerNotFound = errors.New("Listen: no file descriptor passed by systemd")
+
+	// ErrInvalidFileDescriptor is returned when the
+	// address contains an invalid file descriptor.
+	ErrInvalidFileDescriptor = errors.New("Listen: invalid file descriptor")
+)
+
+// listen announces on the address, which is one of:
+//
+//   - a TCP network address, e.g. ":8080" or "127.0.0.1:8080"
+//   - a Unix domain socket, prefixed by "unix:", e.g. "unix:/run/app.sock"
+//   - an inherited file descriptor, prefixed by "fd:", e.g. "fd:3"
+//   - a named file descriptor passed by the socket activation of systemd,
+//     prefixed by "systemd:", e.g. "systemd:http" (see FileDescriptorName=)
+func listen(addr string) (net.Listener, error) {
+	network, address, _ := strings.Cut(addr, ":")
+	switch network {
+	case "unix":
+		return net.Listen("unix", address)
+	case "fd":
+		fd, err := strconv.Atoi(address)
+		if err != nil || fd < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFileDescriptor, address)
+		}
+		return fileListener(fd, addr)
+	case "systemd":
+		fd, err := systemdFd(address)
+		if err != nil {
+			return nil, err
+		}
+		return fileListener(fd, addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// fileListener returns a copy of the network listener
+// corresponding to the open file descriptor fd.
+// The file descriptor itself is closed afterwards.
+func fileListener(fd int, name string) (net.Listener, error) {
+	f := os.NewFile(uintptr(fd), name)
+	if f == nil {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFileDescriptor, fd)
+	}
+	defer f.Close()
+	return net.FileListener(f)
+}
+
+// systemdFd returns the named file descriptor passed to the process by the
+// socket activation of systemd, according to the LISTEN_PID, LISTEN_FDS and
+// LISTEN_FDNAMES environment variables.
+func systemdFd(name string) (int, error) {
+	// The variables may be inherited by child processes,
+	// thus they are only valid for the process with LISTEN_PID.
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return -1, fmt.Errorf("%w: %q", ErrSystemdListenerNotFound, name)
+	}
+
+	n, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	for i := 0; i < n && i < len(names); i++ {
+		if names[i] == name {
+			return listenFdsStart + i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %q", ErrSystemdListenerNotFound, name)
+}
@@ -0,0 +1,95 @@
+package lungo
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestListen(t *testing.T) {
+	ln, err := listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	assertEqual(t, "tcp", ln.Addr().Network())
+
+	sock := filepath.Join(t.TempDir(), "app.sock")
+	ln, err = listen("unix:" + sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "unix", ln.Addr().Network())
+	assertEqual(t, sock, ln.Addr().String())
+
+	// The socket is removed once the listener is closed.
+	ln.Close()
+	_, err = os.Stat(sock)
+	assertEqual(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestListenFd(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.Close()
+
+	f, err := tcp.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := listen("fd:" + strconv.Itoa(int(f.Fd())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	assertEqual(t, tcp.Addr().String(), ln.Addr().String())
+
+	_, err = listen("fd:invalid")
+	assertEqual(t, true, errors.Is(err, ErrInvalidFileDescriptor))
+
+	_, err = listen("fd:-1")
+	assertEqual(t, true, errors.Is(err, ErrInvalidFileDescriptor))
+}
+
+func TestSystemdFd(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+
+	tests := []struct {
+		name     string
+		pid      string
+		fds      string
+		names    string
+		lookup   string
+		expected int
+		err      error
+	}{
+		{name: "First", pid: pid, fds: "2", names: "http:unix", lookup: "http", expected: 3},
+		{name: "Second", pid: pid, fds: "2", names: "http:unix", lookup: "unix", expected: 4},
+		{name: "UnknownName", pid: pid, fds: "2", names: "http:unix", lookup: "https", expected: -1, err: ErrSystemdListenerNotFound},
+		{name: "ExceedsFds", pid: pid, fds: "1", names: "http:unix", lookup: "unix", expected: -1, err: ErrSystemdListenerNotFound},
+		{name: "OtherProcess", pid: "1", fds: "2", names: "http:unix", lookup: "http", expected: -1, err: ErrSystemdListenerNotFound},
+		{name: "Unset", lookup: "http", expected: -1, err: ErrSystemdListenerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LISTEN_PID", tt.pid)
+			t.Setenv("LISTEN_FDS", tt.fds)
+			t.Setenv("LISTEN_FDNAMES", tt.names)
+
+			fd, err := systemdFd(tt.lookup)
+			assertEqual(t, tt.expected, fd)
+			assertEqual(t, true, errors.Is(err, tt.err))
+		})
+	}
+
+	t.Setenv("LISTEN_PID", "")
+	_, err := listen("systemd:http")
+	assertEqual(t, true, errors.Is(err, ErrSystemdListenerNotFound))
+}
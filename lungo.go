@@ -58,6 +58,7 @@
 	HeaderExpect                  = "Expect"
 	HeaderExpires                 = "Expires"
 	HeaderExt                     = "Ext"
+	HeaderForwarded               = "Forwarded"
 	HeaderFrom                    = "From"
 	HeaderGetProfile              = "GetProfile"
 	HeaderHost                    = "Host"
@@ -128,10 +129,12 @@
 	HeaderWantDigest              = "Want-Digest"
 	HeaderWarning                 = "Warning"
 	HeaderXForwardedFor           = "X-Forwarded-For"
+	HeaderXForwardedHost          = "X-Forwarded-Host"
 	HeaderXForwardedProto         = "X-Forwarded-Proto"
 	HeaderXForwardedProtocol      = "X-Forwarded-Protocol"
 	HeaderXForwardedSsl           = "X-Forwarded-Ssl"
 	HeaderXUrlScheme              = "X-Url-Scheme"
+	HeaderXAPIKey                 = "X-API-Key"
 	HeaderXHTTPMethodOverride     = "X-HTTP-Method-Override"
 	HeaderXRealIP                 = "X-Real-IP"
 	HeaderXRequestID              = "X-Request-ID"
@@ -147,6 +150,15 @@
 	HeaderAccessControlRequestHeaders   = "Access-Control-Request-Headers"
 	HeaderAccessControlRequestMethod    = "Access-Control-Request-Method"
 
+	// Private network access
+	HeaderAccessControlAllowPrivateNetwork   = "Access-Control-Allow-Private-Network"
+	HeaderAccessControlRequestPrivateNetwork = "Access-Control-Request-Private-Network"
+
+	// Rate limiting
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+
 	// Security
 	HeaderContentSecurityPolicy           = "Content-Security-Policy"
 	HeaderContentSecurityPolicyReportOnly = "Content-Security-Policy-Report-Only"
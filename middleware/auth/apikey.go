@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// extractor extracts the API key from a request.
+type extractor func(c *lungo.Context) string
+
+// APIKey creates a new API key middleware instance
+//
+// It panics if the Lookup of the configuration is invalid.
+func APIKey(configure ...func(*APIKeyConfig)) lungo.Middleware {
+	config := new(APIKeyConfig)
+	*config = *DefaultAPIKeyConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	extractors := newExtractors(config.Lookup)
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			for _, extract := range extractors {
+				key := extract(c)
+				if key == "" {
+					continue
+				}
+
+				if config.Validator != nil {
+					if principal, ok := config.Validator(c, key); ok {
+						c.Set(lungo.PrincipalKey, principal)
+						return next.ServeHTTP(c)
+					}
+				}
+				break
+			}
+
+			return c.Error(http.StatusUnauthorized)
+		})
+	}
+}
+
+// newExtractors creates the extractors of the API key from the lookup.
+// It panics if the lookup contains an unknown source.
+func newExtractors(lookup string) []extractor {
+	extractors := make([]extractor, 0)
+	for _, l := range strings.Split(lookup, ",") {
+		source, name, ok := strings.Cut(strings.TrimSpace(l), ":")
+		if !ok || name == "" {
+			panic(fmt.Sprintf("Invalid lookup. The API key lookup `%s` must be of the form `<source>:<name>`.", l))
+		}
+
+		switch source {
+		case "header":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				return c.Header(name)
+			})
+		case "query":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				return c.Request.URL.Query().Get(name)
+			})
+		case "cookie":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				cookie, err := c.Cookie(name)
+				if err != nil {
+					return ""
+				}
+				return cookie.Value
+			})
+		default:
+			panic(fmt.Sprintf("Invalid lookup. The API key source `%s` is unknown.", source))
+		}
+	}
+	return extractors
+}
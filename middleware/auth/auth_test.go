@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+type testcase struct {
+	middleware   lungo.Middleware
+	request      func(r *http.Request)
+	code         int
+	authenticate string
+	principal    any
+}
+
+func run(t *testing.T, tests []testcase) {
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testcase.request != nil {
+			testcase.request(req)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		var principal any
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			principal = c.Principal()
+			return c.NoContent()
+		}))
+		if err := h.ServeHTTP(c); err != nil {
+			app.HandleError(c, err)
+		}
+
+		assertEqual(t, testcase.code, rr.Code)
+		assertEqual(t, testcase.authenticate, rr.Header().Get(lungo.HeaderWWWAuthenticate))
+		assertEqual(t, testcase.principal, principal)
+	}
+}
+
+func TestBasic(t *testing.T) {
+	users := func(c *BasicConfig) {
+		c.Users = map[string]string{"foo": "bar", "baz": "qux"}
+	}
+
+	run(t, []testcase{
+		{
+			middleware:   Basic(users),
+			code:         http.StatusUnauthorized,
+			authenticate: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		{
+			middleware: Basic(users),
+			request: func(r *http.Request) {
+				r.SetBasicAuth("foo", "bar")
+			},
+			code:      http.StatusNoContent,
+			principal: "foo",
+		},
+		{
+			middleware: Basic(users),
+			request: func(r *http.Request) {
+				r.SetBasicAuth("foo", "qux")
+			},
+			code:         http.StatusUnauthorized,
+			authenticate: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		{
+			middleware: Basic(users, func(c *BasicConfig) {
+				c.Realm = "Admin"
+			}),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderAuthorization, "Basic invalid")
+			},
+			code:         http.StatusUnauthorized,
+			authenticate: `Basic realm="Admin", charset="UTF-8"`,
+		},
+		{
+			middleware: Basic(users, func(c *BasicConfig) {
+				c.Validator = func(c *lungo.Context, username, password string) (any, bool) {
+					return 42, username == "admin" && password == "secret"
+				}
+			}),
+			request: func(r *http.Request) {
+				r.SetBasicAuth("admin", "secret")
+			},
+			code:      http.StatusNoContent,
+			principal: 42,
+		},
+	})
+}
+
+func TestBearer(t *testing.T) {
+	validator := func(c *BearerConfig) {
+		c.Validator = func(c *lungo.Context, token string) (any, bool) {
+			return "foo", token == "token"
+		}
+	}
+
+	run(t, []testcase{
+		{
+			middleware:   Bearer(validator),
+			code:         http.StatusUnauthorized,
+			authenticate: `Bearer realm="Restricted"`,
+		},
+		{
+			middleware: Bearer(validator),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderAuthorization, "Basic token")
+			},
+			code:         http.StatusUnauthorized,
+			authenticate: `Bearer realm="Restricted"`,
+		},
+		{
+			middleware: Bearer(validator),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderAuthorization, "bearer token")
+			},
+			code:      http.StatusNoContent,
+			principal: "foo",
+		},
+		{
+			middleware: Bearer(validator),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderAuthorization, "Bearer invalid")
+			},
+			code:         http.StatusUnauthorized,
+			authenticate: `Bearer realm="Restricted", error="invalid_token"`,
+		},
+		{
+			middleware: Bearer(),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderAuthorization, "Bearer token")
+			},
+			code:         http.StatusUnauthorized,
+			authenticate: `Bearer realm="Restricted", error="invalid_token"`,
+		},
+	})
+}
+
+func TestAPIKey(t *testing.T) {
+	validator := func(c *APIKeyConfig) {
+		c.Validator = func(c *lungo.Context, key string) (any, bool) {
+			return "foo", key == "key"
+		}
+	}
+
+	run(t, []testcase{
+		{
+			middleware: APIKey(validator),
+			code:       http.StatusUnauthorized,
+		},
+		{
+			middleware: APIKey(validator),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderXAPIKey, "key")
+			},
+			code:      http.StatusNoContent,
+			principal: "foo",
+		},
+		{
+			middleware: APIKey(validator),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderXAPIKey, "invalid")
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			middleware: APIKey(validator, func(c *APIKeyConfig) {
+				c.Lookup = "header:Authorization, query:api_key, cookie:api_key"
+			}),
+			request: func(r *http.Request) {
+				r.URL.RawQuery = "api_key=key"
+			},
+			code:      http.StatusNoContent,
+			principal: "foo",
+		},
+		{
+			middleware: APIKey(validator, func(c *APIKeyConfig) {
+				c.Lookup = "query:api_key,cookie:api_key"
+			}),
+			request: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "api_key", Value: "key"})
+			},
+			code:      http.StatusNoContent,
+			principal: "foo",
+		},
+		{
+			middleware: APIKey(),
+			request: func(r *http.Request) {
+				r.Header.Set(lungo.HeaderXAPIKey, "key")
+			},
+			code: http.StatusUnauthorized,
+		},
+	})
+}
+
+func TestAPIKeyInvalidLookup(t *testing.T) {
+	for _, lookup := range []string{"header", "header:", "form:key"} {
+		func() {
+			defer func() {
+				assertEqual(t, true, recover() != nil)
+			}()
+			APIKey(func(c *APIKeyConfig) {
+				c.Lookup = lookup
+			})
+		}()
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Basic creates a new basic auth middleware instance
+//
+// see: https://datatracker.ietf.org/doc/html/rfc7617
+func Basic(configure ...func(*BasicConfig)) lungo.Middleware {
+	config := new(BasicConfig)
+	*config = *DefaultBasicConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	challenge := fmt.Sprintf(`Basic realm=%q, charset="UTF-8"`, config.Realm)
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			username, password, ok := c.Request.BasicAuth()
+			if ok {
+				if principal, ok := config.authenticate(c, username, password); ok {
+					c.Set(lungo.PrincipalKey, principal)
+					return next.ServeHTTP(c)
+				}
+			}
+
+			c.SetHeader(lungo.HeaderWWWAuthenticate, challenge)
+			return c.Error(http.StatusUnauthorized)
+		})
+	}
+}
+
+// authenticate validates the credentials of the request. The passwords
+// of all Users are compared in constant time to prevent timing attacks.
+func (config *BasicConfig) authenticate(c *lungo.Context, username, password string) (any, bool) {
+	found := false
+	for u, p := range config.Users {
+		if secureCompare(u, username)&secureCompare(p, password) == 1 {
+			found = true
+		}
+	}
+	if found {
+		return username, true
+	}
+
+	if config.Validator != nil {
+		return config.Validator(c, username, password)
+	}
+	return nil, false
+}
+
+// secureCompare compares the strings in constant time and returns 1
+// if they are equal or 0 otherwise. The strings are hashed first,
+// such that their length is not leaked.
+func secureCompare(x, y string) int {
+	hx := sha256.Sum256([]byte(x))
+	hy := sha256.Sum256([]byte(y))
+	return subtle.ConstantTimeCompare(hx[:], hy[:])
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// bearerScheme is the authentication scheme of bearer tokens.
+const bearerScheme = "Bearer"
+
+// Bearer creates a new bearer auth middleware instance
+//
+// see: https://datatracker.ietf.org/doc/html/rfc6750
+func Bearer(configure ...func(*BearerConfig)) lungo.Middleware {
+	config := new(BearerConfig)
+	*config = *DefaultBearerConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	challenge := fmt.Sprintf(`%s realm=%q`, bearerScheme, config.Realm)
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			token, ok := BearerToken(c)
+			if !ok {
+				c.SetHeader(lungo.HeaderWWWAuthenticate, challenge)
+				return c.Error(http.StatusUnauthorized)
+			}
+
+			if config.Validator != nil {
+				if principal, ok := config.Validator(c, token); ok {
+					c.Set(lungo.PrincipalKey, principal)
+					return next.ServeHTTP(c)
+				}
+			}
+
+			c.SetHeader(lungo.HeaderWWWAuthenticate, challenge+`, error="invalid_token"`)
+			return c.Error(http.StatusUnauthorized)
+		})
+	}
+}
+
+// BearerToken extracts the bearer token from the `Authorization`
+// header of the request. It reports whether a token was found.
+func BearerToken(c *lungo.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(c.Header(lungo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"github.com/felix-kaestner/lungo"
+)
+
+// BasicConfig defines the configuration options for the basic auth middleware
+type BasicConfig struct {
+	// Realm defines the protection space of the credentials,
+	// which is sent inside of the `WWW-Authenticate` header.
+	//
+	// Optional. Default value "Restricted".
+	Realm string
+
+	// Users defines a map of usernames to passwords of valid credentials.
+	// The username is stored as the principal of authenticated requests.
+	//
+	// Optional. Default value nil.
+	Users map[string]string
+
+	// Validator defines a function to validate the credentials of a request,
+	// which are not contained in Users. It returns the principal of the
+	// request and whether or not the credentials are valid.
+	//
+	// Optional. Default value nil.
+	Validator func(c *lungo.Context, username, password string) (any, bool)
+}
+
+// DefaultBasicConfig contains the default value for the
+// basic auth middleware configuration
+var DefaultBasicConfig = &BasicConfig{
+	Realm:     "Restricted",
+	Users:     nil,
+	Validator: nil,
+}
+
+// BearerConfig defines the configuration options for the bearer auth middleware
+type BearerConfig struct {
+	// Realm defines the protection space of the token,
+	// which is sent inside of the `WWW-Authenticate` header.
+	//
+	// Optional. Default value "Restricted".
+	Realm string
+
+	// Validator defines a function to validate the bearer token of a request.
+	// It returns the principal of the request and whether or not the token is
+	// valid. Requests are rejected, if no Validator is set.
+	//
+	// Required. Default value nil.
+	Validator func(c *lungo.Context, token string) (any, bool)
+}
+
+// DefaultBearerConfig contains the default value for the
+// bearer auth middleware configuration
+var DefaultBearerConfig = &BearerConfig{
+	Realm:     "Restricted",
+	Validator: nil,
+}
+
+// APIKeyConfig defines the configuration options for the API key middleware
+type APIKeyConfig struct {
+	// Lookup defines a comma-separated list of "<source>:<name>" pairs,
+	// from which the API key is extracted in the given order.
+	//
+	// Possible sources:
+	// - "header:<name>"
+	// - "query:<name>"
+	// - "cookie:<name>"
+	//
+	// Optional. Default value "header:X-API-Key".
+	Lookup string
+
+	// Validator defines a function to validate the API key of a request.
+	// It returns the principal of the request and whether or not the key
+	// is valid. Requests are rejected, if no Validator is set.
+	//
+	// Required. Default value nil.
+	Validator func(c *lungo.Context, key string) (any, bool)
+}
+
+// DefaultAPIKeyConfig contains the default value for the
+// API key middleware configuration
+var DefaultAPIKeyConfig = &APIKeyConfig{
+	Lookup:    "header:" + lungo.HeaderXAPIKey,
+	Validator: nil,
+}
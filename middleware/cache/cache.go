@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Stats counts the cache hits and misses.
+// It is safe for concurrent use.
+type Stats struct {
+	hits   uint64
+	misses uint64
+}
+
+// Hits returns the number of responses served from the cache.
+func (s *Stats) Hits() uint64 {
+	return atomic.LoadUint64(&s.hits)
+}
+
+// Misses returns the number of responses not served from the cache.
+func (s *Stats) Misses() uint64 {
+	return atomic.LoadUint64(&s.misses)
+}
+
+// New creates a new cache middleware instance
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewMemoryStore(1024)
+	}
+
+	generateKey := config.KeyGenerator
+	if generateKey == nil {
+		generateKey = func(c *lungo.Context) string {
+			return key(c, config.VaryHeaders)
+		}
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			if c.Method() != http.MethodGet && c.Method() != http.MethodHead {
+				return next.ServeHTTP(c)
+			}
+
+			// Responses to requests with credentials are specific to the
+			// client, thus they are neither served from nor stored in the
+			// shared cache (see RFC 7234 Section 3.2).
+			if c.Header(lungo.HeaderAuthorization) != "" || c.Header(lungo.HeaderCookie) != "" {
+				return next.ServeHTTP(c)
+			}
+
+			// A request with `no-cache` or `max-age=0` must not be served from
+			// the cache, but its response can be stored unless `no-store` is set.
+			directives := parseCacheControl(c.Header(lungo.HeaderCacheControl))
+			_, noStore := directives["no-store"]
+			_, noCache := directives["no-cache"]
+
+			k := generateKey(c)
+
+			if !noStore && !noCache && directives["max-age"] != "0" {
+				if entry, err := store.Get(k); err == nil && entry != nil && matchVary(c.Request, entry.Vary) {
+					if config.Stats != nil {
+						atomic.AddUint64(&config.Stats.hits, 1)
+					}
+					return serve(c, entry, config.Header)
+				}
+			}
+
+			if config.Stats != nil {
+				atomic.AddUint64(&config.Stats.misses, 1)
+			}
+
+			if config.Header != "" {
+				c.SetHeader(config.Header, "MISS")
+			}
+
+			w := &writer{ResponseWriter: c.Response}
+			c.Response = w
+			err := next.ServeHTTP(c)
+			c.Response = w.ResponseWriter
+
+			// Responses to HEAD requests are not stored, since they lack
+			// the body, which is required to serve subsequent GET requests.
+			if err != nil || noStore || w.hijacked || c.Method() == http.MethodHead {
+				return err
+			}
+
+			if ttl, ok := cacheable(w, config.Expiration); ok {
+				w.header.Del(config.Header)
+				entry := &Entry{
+					Status: w.code,
+					Header: w.header,
+					Body:   w.buf.Bytes(),
+					Stored: time.Now(),
+					Vary:   vary(c.Request, w.header),
+				}
+				// Errors of the store are ignored, since the
+				// response has already been sent to the client.
+				store.Set(k, entry, ttl)
+			}
+
+			return nil
+		})
+	}
+}
+
+// serve replies to the request with the cached response.
+func serve(c *lungo.Context, entry *Entry, header string) error {
+	h := c.Response.Header()
+	for k, v := range entry.Header {
+		h[k] = append([]string(nil), v...)
+	}
+
+	h.Set(lungo.HeaderAge, strconv.Itoa(int(time.Since(entry.Stored).Seconds())))
+	if header != "" {
+		h.Set(header, "HIT")
+	}
+
+	c.WriteHeader(entry.Status)
+	if c.Method() == http.MethodHead {
+		return nil
+	}
+	_, err := c.Response.Write(entry.Body)
+	return err
+}
+
+// vary returns the values of the request headers,
+// which are listed by the `Vary` header of the response.
+func vary(r *http.Request, header http.Header) http.Header {
+	var values http.Header
+	for _, v := range header.Values(lungo.HeaderVary) {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if values == nil {
+				values = make(http.Header)
+			}
+			values[http.CanonicalHeaderKey(name)] = append([]string{}, r.Header.Values(name)...)
+		}
+	}
+	return values
+}
+
+// matchVary reports whether the values of the request headers
+// equal the values recorded by `vary()` for a cached response.
+func matchVary(r *http.Request, values http.Header) bool {
+	for name, v := range values {
+		if strings.Join(r.Header.Values(name), ",") != strings.Join(v, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// key generates the cache key from the method, path,
+// query and the values of the vary headers.
+func key(c *lungo.Context, headers []string) string {
+	var b strings.Builder
+
+	// Responses to HEAD requests are equivalent to GET
+	// requests except for the body, thus share the key.
+	b.WriteString(http.MethodGet)
+	b.WriteByte(' ')
+	b.WriteString(c.Path())
+	if q := c.Request.URL.Query(); len(q) > 0 {
+		b.WriteByte('?')
+		b.WriteString(q.Encode())
+	}
+
+	for _, h := range headers {
+		b.WriteByte('\n')
+		b.WriteString(http.CanonicalHeaderKey(h))
+		b.WriteByte(':')
+		b.WriteString(strings.Join(c.Request.Header.Values(h), ","))
+	}
+
+	return b.String()
+}
+
+// cacheable reports whether the recorded response may be stored
+// and returns how long it can be cached.
+func cacheable(w *writer, expiration time.Duration) (time.Duration, bool) {
+	if w.code != http.StatusOK || w.header == nil {
+		return 0, false
+	}
+
+	if w.header.Get(lungo.HeaderSetCookie) != "" || w.header.Get(lungo.HeaderVary) == "*" {
+		return 0, false
+	}
+
+	directives := parseCacheControl(w.header.Get(lungo.HeaderCacheControl))
+	for _, d := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[d]; ok {
+			return 0, false
+		}
+	}
+
+	ttl := expiration
+	for _, d := range []string{"s-maxage", "max-age"} {
+		if v, ok := directives[d]; ok {
+			seconds, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, false
+			}
+			ttl = time.Duration(seconds) * time.Second
+			break
+		}
+	}
+
+	return ttl, ttl > 0
+}
+
+// parseCacheControl parses the directives of a `Cache-Control` header.
+func parseCacheControl(cc string) map[string]string {
+	directives := make(map[string]string)
+	for _, s := range strings.Split(cc, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(s, "=")
+		directives[strings.ToLower(strings.TrimSpace(k))] = strings.Trim(strings.TrimSpace(v), `"`)
+	}
+	return directives
+}
@@ -0,0 +1,360 @@
+package cache
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func TestCache(t *testing.T) {
+	type request struct {
+		method string
+		target string
+		header map[string]string
+		cached bool
+	}
+
+	var tests = []struct {
+		cacheControl string
+		code         int
+		cookie       bool
+		requests     []request
+		configure    func(*Config)
+	}{
+		{
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: true},
+				{method: http.MethodHead, target: "/", cached: true},
+				{method: http.MethodPost, target: "/", cached: false},
+				{target: "/?b=2&a=1", cached: false},
+				{target: "/?a=1&b=2", cached: true},
+				{target: "/", header: map[string]string{lungo.HeaderAccept: lungo.MIMEApplicationJSON}, cached: false},
+				{target: "/", header: map[string]string{lungo.HeaderAccept: lungo.MIMEApplicationJSON}, cached: true},
+			},
+		},
+		{
+			requests: []request{
+				{target: "/", header: map[string]string{lungo.HeaderCacheControl: "no-store"}, cached: false},
+				{target: "/", cached: false},
+				{target: "/", header: map[string]string{lungo.HeaderCacheControl: "no-cache"}, cached: false},
+				{target: "/", header: map[string]string{lungo.HeaderCacheControl: "max-age=0"}, cached: false},
+				{target: "/", cached: true},
+			},
+		},
+		{
+			cacheControl: "private, max-age=60",
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			cacheControl: "no-store",
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			cacheControl: "public, max-age=0",
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			cacheControl: "public, max-age=0, s-maxage=60",
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: true},
+			},
+		},
+		{
+			code: http.StatusCreated,
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			cookie: true,
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			configure: func(c *Config) {
+				c.Expiration = -time.Second
+			},
+			requests: []request{
+				{target: "/", cached: false},
+				{target: "/", cached: false},
+			},
+		},
+		{
+			configure: func(c *Config) {
+				c.KeyGenerator = func(c *lungo.Context) string {
+					return "static"
+				}
+			},
+			requests: []request{
+				{target: "/foo", cached: false},
+				{target: "/bar", cached: true},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		stats := &Stats{}
+		calls := 0
+		app := lungo.New()
+
+		configure := testcase.configure
+		if configure == nil {
+			configure = func(c *Config) {}
+		}
+
+		h := New(func(c *Config) {
+			c.Stats = stats
+		}, configure)(lungo.HandlerFunc(func(c *lungo.Context) error {
+			calls++
+			if testcase.cacheControl != "" {
+				c.SetHeader(lungo.HeaderCacheControl, testcase.cacheControl)
+			}
+			if testcase.cookie {
+				c.SetCookie(&http.Cookie{Name: "session", Value: "1"})
+			}
+			code := testcase.code
+			if code == 0 {
+				code = http.StatusOK
+			}
+			return c.Text(code, c.Path())
+		}))
+
+		hits, misses := 0, 0
+		for _, r := range testcase.requests {
+			method := r.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(method, r.target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range r.header {
+				req.Header.Set(k, v)
+			}
+
+			c := app.NewContext(rr, req)
+			before := calls
+			assertEqual(t, nil, h.ServeHTTP(c))
+
+			assertEqual(t, r.cached, before == calls)
+			if method == http.MethodPost {
+				continue
+			}
+
+			if r.cached {
+				hits++
+				assertEqual(t, "HIT", rr.Header().Get("X-Cache"))
+				assertEqual(t, "0", rr.Header().Get(lungo.HeaderAge))
+				assertEqual(t, lungo.MIMETextPlain, rr.Header().Get(lungo.HeaderContentType))
+			} else {
+				misses++
+				assertEqual(t, "MISS", rr.Header().Get("X-Cache"))
+			}
+		}
+
+		assertEqual(t, uint64(hits), stats.Hits())
+		assertEqual(t, uint64(misses), stats.Misses())
+	}
+}
+
+func TestCacheBody(t *testing.T) {
+	app := lungo.New()
+	store := NewMemoryStore(10)
+
+	h := New(func(c *Config) {
+		c.Store = store
+		c.Header = ""
+	})(lungo.HandlerFunc(func(c *lungo.Context) error {
+		return c.Json(http.StatusOK, lungo.Map{"message": "Hello, world!"})
+	}))
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertEqual(t, nil, h.ServeHTTP(app.NewContext(rr, req)))
+		assertEqual(t, http.StatusOK, rr.Code)
+		assertEqual(t, "", rr.Header().Get("X-Cache"))
+		assertEqual(t, lungo.MIMEApplicationJSON, rr.Header().Get(lungo.HeaderContentType))
+		bodies = append(bodies, rr.Body.String())
+	}
+
+	assertEqual(t, 1, store.Len())
+	assertEqual(t, bodies[0], bodies[1])
+}
+
+func TestCacheCredentials(t *testing.T) {
+	app := lungo.New()
+	store := NewMemoryStore(10)
+
+	h := New(func(c *Config) {
+		c.Store = store
+	})(lungo.HandlerFunc(func(c *lungo.Context) error {
+		return c.Text(http.StatusOK, "user="+c.Header(lungo.HeaderAuthorization)+c.Header(lungo.HeaderCookie))
+	}))
+
+	tests := []struct {
+		header map[string]string
+		body   string
+		cache  string
+	}{
+		{header: map[string]string{lungo.HeaderAuthorization: "Bearer alice"}, body: "user=Bearer alice"},
+		{body: "user=", cache: "MISS"},
+		{header: map[string]string{lungo.HeaderCookie: "session=bob"}, body: "user=session=bob"},
+		{body: "user=", cache: "HIT"},
+		{header: map[string]string{lungo.HeaderAuthorization: "Bearer alice"}, body: "user=Bearer alice"},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
+		assertEqual(t, nil, h.ServeHTTP(app.NewContext(rr, req)))
+		assertEqual(t, testcase.body, rr.Body.String())
+		assertEqual(t, testcase.cache, rr.Header().Get("X-Cache"))
+	}
+
+	assertEqual(t, 1, store.Len())
+}
+
+func TestCacheHead(t *testing.T) {
+	app := lungo.New()
+
+	// The body of responses to HEAD requests is omitted by http.ServeContent.
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		http.ServeContent(c.Response, c.Request, "hello.txt", time.Time{}, strings.NewReader("Hello"))
+		return nil
+	}))
+
+	tests := []struct {
+		method string
+		body   string
+		cache  string
+	}{
+		{method: http.MethodHead, cache: "MISS"},
+		{method: http.MethodGet, body: "Hello", cache: "MISS"},
+		{method: http.MethodHead, cache: "HIT"},
+		{method: http.MethodGet, body: "Hello", cache: "HIT"},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(testcase.method, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertEqual(t, nil, h.ServeHTTP(app.NewContext(rr, req)))
+		assertEqual(t, http.StatusOK, rr.Code)
+		assertEqual(t, "5", rr.Header().Get(lungo.HeaderContentLength))
+		assertEqual(t, testcase.body, rr.Body.String())
+		assertEqual(t, testcase.cache, rr.Header().Get("X-Cache"))
+	}
+}
+
+func TestCacheVary(t *testing.T) {
+	app := lungo.New()
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		c.SetHeader(lungo.HeaderVary, "Accept-Language")
+		return c.Text(http.StatusOK, c.Header("Accept-Language"))
+	}))
+
+	tests := []struct {
+		language string
+		cache    string
+	}{
+		{language: "en", cache: "MISS"},
+		{language: "en", cache: "HIT"},
+		{language: "de", cache: "MISS"},
+		{language: "de", cache: "HIT"},
+		{language: "", cache: "MISS"},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testcase.language != "" {
+			req.Header.Set("Accept-Language", testcase.language)
+		}
+
+		assertEqual(t, nil, h.ServeHTTP(app.NewContext(rr, req)))
+		assertEqual(t, testcase.language, rr.Body.String())
+		assertEqual(t, testcase.cache, rr.Header().Get("X-Cache"))
+	}
+}
+
+type hijacker struct {
+	*httptest.ResponseRecorder
+}
+
+func (h *hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func TestCacheHijack(t *testing.T) {
+	app := lungo.New()
+	store := NewMemoryStore(10)
+
+	h := New(func(c *Config) {
+		c.Store = store
+	})(lungo.HandlerFunc(func(c *lungo.Context) error {
+		c.WriteHeader(http.StatusOK)
+		_, _, err := c.Hijack()
+		return err
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := &hijacker{httptest.NewRecorder()}
+	assertEqual(t, nil, h.ServeHTTP(app.NewContext(rr, req)))
+	assertEqual(t, 0, store.Len())
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the cache middleware
+type Config struct {
+	// Store defines the storage of the cached responses.
+	//
+	// Optional. Default: NewMemoryStore(1024)
+	Store Store
+
+	// Expiration defines how long a response is cached, unless the
+	// response specifies a `max-age` or `s-maxage` Cache-Control directive.
+	//
+	// Optional. Default: 1 * time.Minute
+	Expiration time.Duration
+
+	// VaryHeaders defines a list of request headers, whose values are
+	// part of the cache key, e.g. `Accept-Language`. The responses for
+	// requests with different values of these headers are cached separately.
+	//
+	// Optional. Default: []string{lungo.HeaderAccept, lungo.HeaderAcceptEncoding}
+	VaryHeaders []string
+
+	// KeyGenerator defines a function to generate the cache key for a
+	// request. By default the key is built from the method, path, query
+	// and the values of the VaryHeaders.
+	//
+	// Optional. Default: nil
+	KeyGenerator func(c *lungo.Context) string
+
+	// Header defines the response header which indicates whether the
+	// response was served from the cache ("HIT") or not ("MISS").
+	// Set this value to "" to disable the header.
+	//
+	// Optional. Default: "X-Cache"
+	Header string
+
+	// Stats defines the counters of cache hits and misses.
+	//
+	// Optional. Default: nil
+	Stats *Stats
+}
+
+// DefaultConfig contains the default value for the
+// cache middleware configuration
+var DefaultConfig = &Config{
+	Store:        nil,
+	Expiration:   1 * time.Minute,
+	VaryHeaders:  []string{lungo.HeaderAccept, lungo.HeaderAcceptEncoding},
+	KeyGenerator: nil,
+	Header:       "X-Cache",
+	Stats:        nil,
+}
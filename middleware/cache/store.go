@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	Stored time.Time   `json:"stored"`
+
+	// Vary contains the values of the request headers listed by the
+	// `Vary` header of the response. The entry is only served to
+	// requests with the same values of these headers.
+	Vary http.Header `json:"vary,omitempty"`
+}
+
+// Store is the interface of a storage for cached responses.
+// Implementations must be safe for concurrent use.
+//
+// Get returns a nil Entry if there is no entry for the key.
+// The entry of a key expires after the provided time to live.
+// Errors of a Store are treated as cache misses.
+type Store interface {
+	Get(key string) (*Entry, error)
+	Set(key string, entry *Entry, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// item is an element of the MemoryStore.
+type item struct {
+	key     string
+	entry   *Entry
+	expires time.Time
+}
+
+// MemoryStore is an in-memory Store, which evicts the
+// least recently used entries once the capacity is reached.
+type MemoryStore struct {
+	mutex    sync.Mutex
+	capacity int
+	list     *list.List
+	items    map[string]*list.Element
+}
+
+// NewMemoryStore creates a new MemoryStore instance
+// which holds at most capacity entries.
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		capacity: capacity,
+		list:     list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements the Store interface.
+func (s *MemoryStore) Get(key string) (*Entry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	i := e.Value.(*item)
+	if time.Now().After(i.expires) {
+		s.remove(e)
+		return nil, nil
+	}
+
+	s.list.MoveToFront(e)
+	return i.entry, nil
+}
+
+// Set implements the Store interface.
+func (s *MemoryStore) Set(key string, entry *Entry, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if e, ok := s.items[key]; ok {
+		i := e.Value.(*item)
+		i.entry, i.expires = entry, expires
+		s.list.MoveToFront(e)
+		return nil
+	}
+
+	s.items[key] = s.list.PushFront(&item{key: key, entry: entry, expires: expires})
+	for s.capacity > 0 && s.list.Len() > s.capacity {
+		s.remove(s.list.Back())
+	}
+	return nil
+}
+
+// Delete implements the Store interface.
+func (s *MemoryStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		s.remove(e)
+	}
+	return nil
+}
+
+// Len returns the number of entries in the store.
+func (s *MemoryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.list.Len()
+}
+
+// remove removes the element from the store.
+func (s *MemoryStore) remove(e *list.Element) {
+	s.list.Remove(e)
+	delete(s.items, e.Value.(*item).key)
+}
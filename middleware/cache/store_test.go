@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore(2)
+
+	entry, err := s.Get("a")
+	assertEqual(t, nil, err)
+	assertEqual(t, true, entry == nil)
+
+	a, b, c := &Entry{Body: []byte("a")}, &Entry{Body: []byte("b")}, &Entry{Body: []byte("c")}
+	s.Set("a", a, time.Minute)
+	s.Set("b", b, time.Minute)
+
+	// Access "a" to make "b" the least recently used entry
+	entry, _ = s.Get("a")
+	assertEqual(t, a, entry)
+
+	s.Set("c", c, time.Minute)
+	assertEqual(t, 2, s.Len())
+
+	entry, _ = s.Get("b")
+	assertEqual(t, true, entry == nil)
+	entry, _ = s.Get("c")
+	assertEqual(t, c, entry)
+
+	// Update an existing entry
+	s.Set("c", b, time.Minute)
+	entry, _ = s.Get("c")
+	assertEqual(t, b, entry)
+	assertEqual(t, 2, s.Len())
+
+	s.Delete("c")
+	entry, _ = s.Get("c")
+	assertEqual(t, true, entry == nil)
+	assertEqual(t, 1, s.Len())
+
+	// Expired entries are removed
+	s.Set("d", c, -time.Second)
+	entry, _ = s.Get("d")
+	assertEqual(t, true, entry == nil)
+	assertEqual(t, 1, s.Len())
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// writer is a http.ResponseWriter, which records the
+// response while writing it to the client.
+type writer struct {
+	http.ResponseWriter
+	buf      bytes.Buffer
+	code     int
+	header   http.Header
+	hijacked bool
+}
+
+// WriteHeader records the status code and a snapshot of the header.
+func (w *writer) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+		w.header = w.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements the io.Writer interface.
+func (w *writer) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements the http.Flusher interface.
+func (w *writer) Flush() {
+	if w.code == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface.
+// Hijacked responses are not stored in the cache.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.hijacked = true
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("Hijack: response does not implement http.Hijacker")
+}
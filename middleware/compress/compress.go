@@ -0,0 +1,112 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+const (
+	// EncodingGzip is the content coding of the gzip format.
+	EncodingGzip = "gzip"
+	// EncodingDeflate is the content coding of the zlib format.
+	EncodingDeflate = "deflate"
+)
+
+// encoder is the common interface of gzip.Writer and zlib.Writer.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// New creates a new compress middleware instance.
+// It panics if the configured compression level is invalid.
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	if _, err := gzip.NewWriterLevel(io.Discard, config.Level); err != nil {
+		panic(err)
+	}
+
+	pools := map[string]*sync.Pool{
+		EncodingGzip: {
+			New: func() any {
+				w, _ := gzip.NewWriterLevel(io.Discard, config.Level)
+				return w
+			},
+		},
+		EncodingDeflate: {
+			New: func() any {
+				w, _ := zlib.NewWriterLevel(io.Discard, config.Level)
+				return w
+			},
+		},
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) (err error) {
+			// Responses are also wrapped if no encoding is acceptable,
+			// in order to add the `Vary` header to eligible responses.
+			encoding := negotiate(c.Header(lungo.HeaderAcceptEncoding))
+
+			w := &writer{
+				ResponseWriter: c.Response,
+				config:         config,
+				encoding:       encoding,
+				pool:           pools[encoding],
+				head:           c.Method() == http.MethodHead,
+			}
+
+			c.Response = w
+			defer func() {
+				c.Response = w.ResponseWriter
+				if cerr := w.Close(); err == nil {
+					err = cerr
+				}
+			}()
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// negotiate returns the preferred content coding according to the value
+// of an `Accept-Encoding` header or "" if no coding is acceptable.
+// Gzip is preferred over deflate if both have the same quality.
+func negotiate(accept string) string {
+	encoding, quality := "", 0.0
+	for _, s := range strings.Split(accept, ",") {
+		token, params, _ := strings.Cut(s, ";")
+		token = strings.ToLower(strings.TrimSpace(token))
+		if token != EncodingGzip && token != EncodingDeflate {
+			continue
+		}
+
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+
+		if q <= 0 {
+			continue
+		}
+		if q > quality || (q == quality && token == EncodingGzip) {
+			encoding, quality = token, q
+		}
+	}
+	return encoding
+}
@@ -0,0 +1,230 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	var r io.Reader
+	switch encoding {
+	case EncodingGzip:
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gr
+	case EncodingDeflate:
+		zr, err := zlib.NewReader(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = zr
+	default:
+		r = body
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat("Hello, world! ", 100)
+
+	var tests = []struct {
+		method      string
+		accept      string
+		contentType string
+		header      map[string]string
+		code        int
+		body        string
+		encoding    string
+		middleware  lungo.Middleware
+	}{
+		{accept: "", contentType: lungo.MIMETextPlain, body: large, encoding: ""},
+		{accept: "gzip", contentType: lungo.MIMETextPlain, body: large, encoding: EncodingGzip},
+		{accept: "deflate", contentType: lungo.MIMETextPlain, body: large, encoding: EncodingDeflate},
+		{accept: "deflate, gzip", contentType: lungo.MIMETextPlain, body: large, encoding: EncodingGzip},
+		{accept: "deflate, gzip;q=0.5", contentType: lungo.MIMETextPlain, body: large, encoding: EncodingDeflate},
+		{accept: "gzip;q=0, br", contentType: lungo.MIMETextPlain, body: large, encoding: ""},
+		{accept: "gzip", contentType: lungo.MIMEApplicationJSONCharsetUTF8, body: large, encoding: EncodingGzip},
+		{accept: "gzip", contentType: "", body: large, encoding: EncodingGzip},
+		{accept: "gzip", contentType: "image/png", body: large, encoding: ""},
+		{accept: "gzip", contentType: lungo.MIMETextPlain, body: "Hello, world!", encoding: ""},
+		{accept: "gzip", contentType: lungo.MIMETextPlain, code: http.StatusCreated, body: large, encoding: EncodingGzip},
+		{accept: "gzip", contentType: lungo.MIMETextPlain, code: http.StatusPartialContent, body: large, encoding: ""},
+		{accept: "gzip", contentType: lungo.MIMETextPlain, header: map[string]string{lungo.HeaderContentEncoding: "br"}, body: large, encoding: "br"},
+		{method: http.MethodHead, accept: "gzip", contentType: lungo.MIMETextPlain, body: large, encoding: ""},
+		{
+			accept:      "gzip",
+			contentType: lungo.MIMETextPlain,
+			body:        "Hello, world!",
+			encoding:    EncodingGzip,
+			middleware: New(func(c *Config) {
+				c.MinLength = 0
+			}),
+		},
+		{
+			accept:      "gzip",
+			contentType: "image/png",
+			body:        large,
+			encoding:    EncodingGzip,
+			middleware: New(func(c *Config) {
+				c.ContentTypes = []string{"image/"}
+			}),
+		},
+	}
+
+	for _, testcase := range tests {
+		method := testcase.method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(method, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(lungo.HeaderAcceptEncoding, testcase.accept)
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		middleware := testcase.middleware
+		if middleware == nil {
+			middleware = New()
+		}
+
+		h := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			if testcase.contentType != "" {
+				c.SetHeader(lungo.HeaderContentType, testcase.contentType)
+			}
+			c.SetHeader(lungo.HeaderContentLength, "42")
+			for k, v := range testcase.header {
+				c.SetHeader(k, v)
+			}
+			if testcase.code != 0 {
+				c.WriteHeader(testcase.code)
+			}
+			// Write in chunks to test buffering
+			for i := 0; i < len(testcase.body); i += 100 {
+				end := i + 100
+				if end > len(testcase.body) {
+					end = len(testcase.body)
+				}
+				if _, err := io.WriteString(c.Response, testcase.body[i:end]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+
+		assertEqual(t, nil, h.ServeHTTP(c))
+		assertEqual(t, rr, c.Response)
+
+		code := testcase.code
+		if code == 0 {
+			code = http.StatusOK
+		}
+
+		assertEqual(t, code, rr.Code)
+		assertEqual(t, testcase.encoding, rr.Header().Get(lungo.HeaderContentEncoding))
+		assertEqual(t, lungo.HeaderAcceptEncoding, rr.Header().Get(lungo.HeaderVary))
+		if testcase.encoding == EncodingGzip || testcase.encoding == EncodingDeflate {
+			assertEqual(t, "", rr.Header().Get(lungo.HeaderContentLength))
+			assertEqual(t, testcase.body, decode(t, testcase.encoding, rr.Body))
+		} else {
+			assertEqual(t, "42", rr.Header().Get(lungo.HeaderContentLength))
+			assertEqual(t, testcase.body, rr.Body.String())
+		}
+	}
+}
+
+func TestCompressFlush(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(lungo.HeaderAcceptEncoding, "gzip")
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		c.SetHeader(lungo.HeaderContentType, lungo.MIMETextPlain)
+		io.WriteString(c.Response, "Hello, ")
+		c.Flush()
+
+		assertEqual(t, true, rr.Flushed)
+		assertEqual(t, EncodingGzip, rr.Header().Get(lungo.HeaderContentEncoding))
+
+		gr, err := gzip.NewReader(strings.NewReader(rr.Body.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 7)
+		if _, err := io.ReadFull(gr, b); err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(t, "Hello, ", string(b))
+
+		io.WriteString(c.Response, "world!")
+		return nil
+	}))
+
+	assertEqual(t, nil, h.ServeHTTP(c))
+	assertEqual(t, "Hello, world!", decode(t, EncodingGzip, rr.Body))
+}
+
+func TestCompressError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(lungo.HeaderAcceptEncoding, "gzip")
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	h := New()(lungo.NotFoundHandler())
+	err = h.ServeHTTP(c)
+
+	assertEqual(t, true, err != nil)
+	assertEqual(t, rr, c.Response)
+	assertEqual(t, false, rr.Flushed)
+	assertEqual(t, "", rr.Header().Get(lungo.HeaderContentEncoding))
+	assertEqual(t, 0, rr.Body.Len())
+}
+
+func TestCompressInvalidLevel(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Test %s: Expected Panic for invalid level", t.Name())
+		}
+	}()
+	New(func(c *Config) {
+		c.Level = 42
+	})
+}
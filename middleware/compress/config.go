@@ -0,0 +1,48 @@
+package compress
+
+import (
+	"compress/gzip"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the compress middleware
+type Config struct {
+	// Level defines the compression level used for gzip and deflate.
+	// It must be in the range of -2 (HuffmanOnly) to 9 (BestCompression).
+	//
+	// see: https://golang.org/pkg/compress/flate/#pkg-constants
+	//
+	// Optional. Default value -1 (DefaultCompression).
+	Level int
+
+	// MinLength defines the minimum size (in bytes) of a response body
+	// to be compressed. Smaller responses are sent uncompressed, since
+	// the overhead of compression outweighs the benefit.
+	// Responses which are flushed are always compressed.
+	//
+	// Optional. Default value 1024.
+	MinLength int
+
+	// ContentTypes defines a list of media types which are compressed.
+	// An entry ending with "/" matches all subtypes, e.g. "text/".
+	//
+	// Optional. Default value []string{"text/", "application/json", ...}.
+	ContentTypes []string
+}
+
+// DefaultConfig contains the default value for the
+// compress middleware configuration
+var DefaultConfig = &Config{
+	Level:     gzip.DefaultCompression,
+	MinLength: 1024,
+	ContentTypes: []string{
+		"text/",
+		lungo.MIMEApplicationJSON,
+		lungo.MIMEApplicationJavaScript,
+		lungo.MIMEApplicationXML,
+		"application/manifest+json",
+		"application/wasm",
+		"image/svg+xml",
+	},
+}
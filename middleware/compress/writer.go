@@ -0,0 +1,188 @@
+package compress
+
+import (
+	"bufio"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// writer is a http.ResponseWriter, which compresses the response body.
+//
+// The body is buffered until MinLength bytes have been written, the
+// response is flushed or the handler returns. Afterwards it is decided
+// whether or not the response is compressed.
+type writer struct {
+	http.ResponseWriter
+	config   *Config
+	encoding string
+	pool     *sync.Pool
+	head     bool
+	encoder  encoder
+	buf      []byte
+	code     int
+	decided  bool
+}
+
+// WriteHeader records the status code, which is sent
+// once it is decided whether or not to compress.
+func (w *writer) WriteHeader(code int) {
+	if w.decided || w.code != 0 {
+		return
+	}
+	w.code = code
+}
+
+// Write implements the io.Writer interface.
+func (w *writer) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= w.config.MinLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush implements the http.Flusher interface.
+// Flushed responses are compressed regardless of their size.
+func (w *writer) Flush() {
+	if !w.decided {
+		if err := w.decide(true); err != nil {
+			return
+		}
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("Hijack: response does not implement http.Hijacker")
+}
+
+// Close writes any buffered data and closes the encoder.
+func (w *writer) Close() error {
+	if !w.decided {
+		// Nothing was written, leave the response untouched,
+		// such that an error handler can reply to the request.
+		if w.code == 0 && len(w.buf) == 0 {
+			return nil
+		}
+		if err := w.decide(len(w.buf) >= w.config.MinLength); err != nil {
+			return err
+		}
+	}
+
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	w.pool.Put(w.encoder)
+	w.encoder = nil
+	return err
+}
+
+// decide determines whether or not to compress the response,
+// writes the header and the buffered data.
+func (w *writer) decide(large bool) error {
+	w.decided = true
+
+	h := w.Header()
+	if h.Get(lungo.HeaderContentType) == "" && len(w.buf) > 0 {
+		h.Set(lungo.HeaderContentType, http.DetectContentType(w.buf))
+	}
+
+	if !varies(h) {
+		h.Add(lungo.HeaderVary, lungo.HeaderAcceptEncoding)
+	}
+
+	if large && w.compressible() {
+		h.Del(lungo.HeaderContentLength)
+		h.Set(lungo.HeaderContentEncoding, w.encoding)
+		w.encoder = w.pool.Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.code)
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(w.buf)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	return err
+}
+
+// compressible reports whether the response may be compressed.
+func (w *writer) compressible() bool {
+	if w.encoding == "" || w.head {
+		return false
+	}
+
+	switch {
+	case w.code < http.StatusOK && w.code != 0,
+		w.code == http.StatusNoContent,
+		w.code == http.StatusPartialContent,
+		w.code == http.StatusNotModified:
+		return false
+	}
+
+	h := w.Header()
+	if h.Get(lungo.HeaderContentEncoding) != "" {
+		return false
+	}
+
+	mt, _, err := mime.ParseMediaType(h.Get(lungo.HeaderContentType))
+	if err != nil {
+		return false
+	}
+
+	for _, ct := range w.config.ContentTypes {
+		if mt == ct || (strings.HasSuffix(ct, "/") && strings.HasPrefix(mt, ct)) {
+			return true
+		}
+	}
+	return false
+}
+
+// varies reports whether the `Vary` header already contains `Accept-Encoding`.
+func varies(h http.Header) bool {
+	for _, v := range h.Values(lungo.HeaderVary) {
+		for _, s := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(s), lungo.HeaderAcceptEncoding) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,14 +1,36 @@
 package cors
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+)
 
 // Config defines the configuration options for the CORS middleware
 type Config struct {
 	// AllowOrigins defines a list of origins that may access the resource.
+	// An origin may contain a single wildcard "*" to match any subdomain,
+	// e.g. "https://*.example.com". The value "*" allows all origins.
 	//
 	// Optional. Default value []string{"*"}.
 	AllowOrigins []string
 
+	// AllowOriginPatterns defines a list of regular expressions matching
+	// origins that may access the resource. The patterns are compiled once
+	// when creating the middleware, which panics if a pattern is invalid.
+	//
+	// see: https://golang.org/pkg/regexp/syntax/
+	//
+	// Optional. Default value []string{}.
+	AllowOriginPatterns []string
+
+	// AllowOriginFunc defines a function to determine whether an origin may
+	// access the resource, e.g. by looking it up in a database. It is only
+	// called if the origin is not matched by AllowOrigins or AllowOriginPatterns.
+	//
+	// Optional. Default value nil.
+	AllowOriginFunc func(origin string, c *lungo.Context) bool
+
 	// AllowMethods defines a list of http methods allowed when accessing the resource.
 	// This is used in response to a preflight request.
 	//
@@ -17,6 +39,9 @@ type Config struct {
 
 	// AllowHeaders defines a list of request headers that can be used when
 	// making the actual request. This is in response to a preflight request.
+	// Preflight requests asking for any other header are rejected. The value
+	// "*" allows all headers, which are then reflected from the
+	// `Access-Control-Request-Headers` header of the preflight request.
 	//
 	// Optional. Default value []string{}.
 	AllowHeaders []string
@@ -40,15 +65,28 @@ type Config struct {
 	//
 	// Optional. Default value 0.
 	MaxAge int
+
+	// AllowPrivateNetwork defines whether or not requests from a public
+	// network may access the resource inside of a private network. It sets
+	// the `Access-Control-Allow-Private-Network` header in response to a
+	// preflight request asking for private network access.
+	//
+	// see: https://wicg.github.io/private-network-access/
+	//
+	// Optional. Default value false.
+	AllowPrivateNetwork bool
 }
 
 // DefaultConfig contains the default value for the
 // CORS middleware configuration
 var DefaultConfig = &Config{
-	AllowOrigins:     []string{"*"},
-	AllowMethods:     []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodPatch, http.MethodDelete},
-	AllowHeaders:     []string{},
-	AllowCredentials: false,
-	ExposeHeaders:    []string{},
-	MaxAge:           0,
+	AllowOrigins:        []string{"*"},
+	AllowOriginPatterns: []string{},
+	AllowOriginFunc:     nil,
+	AllowMethods:        []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodPatch, http.MethodDelete},
+	AllowHeaders:        []string{},
+	AllowCredentials:    false,
+	ExposeHeaders:       []string{},
+	MaxAge:              0,
+	AllowPrivateNetwork: false,
 }
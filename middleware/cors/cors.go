@@ -21,32 +21,48 @@ func New(configure ...func(*Config)) lungo.Middleware {
 	allowMethods := strings.Join(config.AllowMethods, ",")
 	exposeHeaders := strings.Join(config.ExposeHeaders, ",")
 	maxAge := strconv.Itoa(config.MaxAge)
+	matcher := newOriginMatcher(config)
+
+	methods := make(map[string]struct{}, len(config.AllowMethods))
+	for _, m := range config.AllowMethods {
+		methods[m] = struct{}{}
+	}
+
+	anyHeader := false
+	headers := make(map[string]struct{}, len(config.AllowHeaders))
+	for _, h := range config.AllowHeaders {
+		if h == "*" {
+			anyHeader = true
+		}
+		headers[strings.ToLower(h)] = struct{}{}
+	}
 
 	return func(next lungo.Handler) lungo.Handler {
 		return lungo.HandlerFunc(func(c *lungo.Context) error {
 			origin := c.Header(lungo.HeaderOrigin)
 
 			if origin == "" {
-				if c.Method() != http.MethodOptions {
-					return next.ServeHTTP(c)
-				}
-				return c.NoContent()
+				return next.ServeHTTP(c)
 			}
 
+			// A preflight request is an OPTIONS request, which asks for the
+			// permission to use a specific method in the actual request.
+			// Other OPTIONS requests are dispatched to the handler.
+			requestMethod := c.Header(lungo.HeaderAccessControlRequestMethod)
+			preflight := c.Method() == http.MethodOptions && requestMethod != ""
+
 			allowOrigin := ""
-			for _, o := range config.AllowOrigins {
-				if o == "*" && config.AllowCredentials {
+			if matcher.any {
+				allowOrigin = "*"
+				if config.AllowCredentials {
 					allowOrigin = origin
-					break
-				}
-				if o == "*" || o == origin {
-					allowOrigin = o
-					break
 				}
+			} else if matcher.match(origin, c) {
+				allowOrigin = origin
 			}
 
 			if allowOrigin == "" {
-				if c.Method() != http.MethodOptions {
+				if !preflight {
 					return next.ServeHTTP(c)
 				}
 				return c.NoContent()
@@ -55,16 +71,14 @@ func New(configure ...func(*Config)) lungo.Middleware {
 			// Set Vary: Origin
 			c.SetHeader(lungo.HeaderVary, lungo.HeaderOrigin)
 
-			// Set Allow-Origin
-			c.SetHeader(lungo.HeaderAccessControlAllowOrigin, allowOrigin)
+			// If not preflight, dispatch
+			if !preflight {
+				c.SetHeader(lungo.HeaderAccessControlAllowOrigin, allowOrigin)
 
-			// Set Allow-Credentials if set to true
-			if config.AllowCredentials {
-				c.SetHeader(lungo.HeaderAccessControlAllowCredentials, "true")
-			}
+				if config.AllowCredentials {
+					c.SetHeader(lungo.HeaderAccessControlAllowCredentials, "true")
+				}
 
-			// If not preflight, dispatch
-			if c.Method() != http.MethodOptions {
 				if exposeHeaders != "" {
 					c.SetHeader(lungo.HeaderAccessControlExposeHeaders, exposeHeaders)
 				}
@@ -76,14 +90,46 @@ func New(configure ...func(*Config)) lungo.Middleware {
 			c.AddHeader(lungo.HeaderVary, lungo.HeaderAccessControlRequestMethod)
 			c.AddHeader(lungo.HeaderVary, lungo.HeaderAccessControlRequestHeaders)
 
+			// Reject the preflight request without any CORS headers,
+			// if the requested method or headers are not allowed.
+			if _, ok := methods[requestMethod]; !ok {
+				return c.NoContent()
+			}
+
+			requestHeaders := parseHeaderList(c.Header(lungo.HeaderAccessControlRequestHeaders))
+			if !anyHeader {
+				for _, h := range requestHeaders {
+					if _, ok := headers[strings.ToLower(h)]; !ok {
+						return c.NoContent()
+					}
+				}
+			}
+
+			// Set Allow-Origin
+			c.SetHeader(lungo.HeaderAccessControlAllowOrigin, allowOrigin)
+
+			// Set Allow-Credentials if set to true
+			if config.AllowCredentials {
+				c.SetHeader(lungo.HeaderAccessControlAllowCredentials, "true")
+			}
+
 			// Set Allow-Methods
 			c.SetHeader(lungo.HeaderAccessControlAllowMethods, allowMethods)
 
-			// Set Allow-Headers if not empty
-			if allowHeaders != "" {
+			// Set Allow-Headers, reflect the requested headers if all headers are allowed
+			if anyHeader {
+				if len(requestHeaders) > 0 {
+					c.SetHeader(lungo.HeaderAccessControlAllowHeaders, strings.Join(requestHeaders, ","))
+				}
+			} else if allowHeaders != "" {
 				c.SetHeader(lungo.HeaderAccessControlAllowHeaders, allowHeaders)
 			}
 
+			// Set Allow-Private-Network if requested and allowed
+			if config.AllowPrivateNetwork && c.Header(lungo.HeaderAccessControlRequestPrivateNetwork) == "true" {
+				c.SetHeader(lungo.HeaderAccessControlAllowPrivateNetwork, "true")
+			}
+
 			// Set MaxAge is set
 			if config.MaxAge > 0 {
 				c.SetHeader(lungo.HeaderAccessControlMaxAge, maxAge)
@@ -93,3 +139,14 @@ func New(configure ...func(*Config)) lungo.Middleware {
 		})
 	}
 }
+
+// parseHeaderList splits a comma-separated list of header names.
+func parseHeaderList(list string) []string {
+	headers := make([]string, 0)
+	for _, h := range strings.Split(list, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
@@ -21,6 +21,7 @@ func TestCors(t *testing.T) {
 	var tests = []struct {
 		origin     string
 		method     string
+		header     map[string]string
 		status     int
 		middleware lungo.Middleware
 		eval       func(h http.Header)
@@ -36,7 +37,7 @@ func TestCors(t *testing.T) {
 		{
 			origin:     "",
 			method:     http.MethodOptions,
-			status:     http.StatusNoContent,
+			status:     http.StatusOK,
 			middleware: New(),
 			eval: func(h http.Header) {
 				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowOrigin))
@@ -55,6 +56,7 @@ func TestCors(t *testing.T) {
 		{
 			origin: "http://example.de",
 			method: http.MethodOptions,
+			header: map[string]string{lungo.HeaderAccessControlRequestMethod: http.MethodGet},
 			status: http.StatusNoContent,
 			middleware: New(func(c *Config) {
 				c.AllowOrigins = []string{"http://example.com"}
@@ -126,9 +128,34 @@ func TestCors(t *testing.T) {
 				assertEqual(t, lungo.HeaderAuthorization+","+lungo.HeaderContentType, h.Get(lungo.HeaderAccessControlExposeHeaders))
 			},
 		},
+		{
+			origin: "https://api.example.com",
+			method: http.MethodGet,
+			middleware: New(func(c *Config) {
+				c.AllowOrigins = []string{"https://*.example.com"}
+			}),
+			eval: func(h http.Header) {
+				assertEqual(t, lungo.HeaderOrigin, h.Get(lungo.HeaderVary))
+				assertEqual(t, "https://api.example.com", h.Get(lungo.HeaderAccessControlAllowOrigin))
+			},
+		},
+		{
+			origin: "https://example.com",
+			method: http.MethodGet,
+			middleware: New(func(c *Config) {
+				c.AllowOrigins = []string{}
+				c.AllowOriginFunc = func(origin string, c *lungo.Context) bool {
+					return origin == "https://example.com"
+				}
+			}),
+			eval: func(h http.Header) {
+				assertEqual(t, "https://example.com", h.Get(lungo.HeaderAccessControlAllowOrigin))
+			},
+		},
 		{
 			origin:     "localhost",
 			method:     http.MethodOptions,
+			header:     map[string]string{lungo.HeaderAccessControlRequestMethod: http.MethodGet},
 			middleware: New(),
 			eval: func(h http.Header) {
 				assertEqual(t, lungo.HeaderOrigin, h.Values(lungo.HeaderVary)[0])
@@ -141,6 +168,11 @@ func TestCors(t *testing.T) {
 		{
 			origin: "localhost",
 			method: http.MethodOptions,
+			header: map[string]string{
+				lungo.HeaderAccessControlRequestMethod:  http.MethodPost,
+				lungo.HeaderAccessControlRequestHeaders: "authorization, content-type",
+			},
+			status: http.StatusNoContent,
 			middleware: New(func(c *Config) {
 				c.AllowMethods = []string{http.MethodGet, http.MethodPost}
 				c.AllowHeaders = []string{lungo.HeaderAuthorization, lungo.HeaderContentType}
@@ -156,6 +188,62 @@ func TestCors(t *testing.T) {
 				assertEqual(t, "3600", h.Get(lungo.HeaderAccessControlMaxAge))
 			},
 		},
+		{
+			origin:     "localhost",
+			method:     http.MethodOptions,
+			status:     http.StatusOK,
+			middleware: New(),
+			eval: func(h http.Header) {
+				assertEqual(t, "*", h.Get(lungo.HeaderAccessControlAllowOrigin))
+				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowMethods))
+			},
+		},
+		{
+			origin:     "localhost",
+			method:     http.MethodOptions,
+			header:     map[string]string{lungo.HeaderAccessControlRequestMethod: http.MethodTrace},
+			status:     http.StatusNoContent,
+			middleware: New(),
+			eval: func(h http.Header) {
+				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowOrigin))
+				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowMethods))
+			},
+		},
+		{
+			origin: "localhost",
+			method: http.MethodOptions,
+			header: map[string]string{
+				lungo.HeaderAccessControlRequestMethod:  http.MethodGet,
+				lungo.HeaderAccessControlRequestHeaders: "X-Custom",
+			},
+			status: http.StatusNoContent,
+			middleware: New(func(c *Config) {
+				c.AllowHeaders = []string{lungo.HeaderAuthorization}
+			}),
+			eval: func(h http.Header) {
+				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowOrigin))
+				assertEqual(t, "", h.Get(lungo.HeaderAccessControlAllowHeaders))
+			},
+		},
+		{
+			origin: "localhost",
+			method: http.MethodOptions,
+			header: map[string]string{
+				lungo.HeaderAccessControlRequestMethod:         http.MethodGet,
+				lungo.HeaderAccessControlRequestHeaders:        "X-Custom, X-Other",
+				lungo.HeaderAccessControlRequestPrivateNetwork: "true",
+			},
+			status: http.StatusNoContent,
+			middleware: New(func(c *Config) {
+				c.AllowHeaders = []string{"*"}
+				c.AllowPrivateNetwork = true
+			}),
+			eval: func(h http.Header) {
+				assertEqual(t, "*", h.Get(lungo.HeaderAccessControlAllowOrigin))
+				assertEqual(t, "X-Custom,X-Other", h.Get(lungo.HeaderAccessControlAllowHeaders))
+				assertEqual(t, "true", h.Get(lungo.HeaderAccessControlAllowPrivateNetwork))
+			},
+		},
 	}
 
 	for _, testcase := range tests {
@@ -169,10 +257,16 @@ func TestCors(t *testing.T) {
 			req.Header.Set(lungo.HeaderOrigin, testcase.origin)
 		}
 
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
 		app := lungo.New()
 		c := app.NewContext(rr, req)
 
-		h := testcase.middleware(lungo.NotFoundHandler())
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			return c.Text(http.StatusOK, "OK")
+		}))
 		h.ServeHTTP(c)
 
 		testcase.eval(rr.Header())
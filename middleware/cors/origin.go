@@ -0,0 +1,84 @@
+package cors
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// wildcard is an origin containing a single "*",
+// which matches any subdomain, e.g. "https://*.example.com".
+type wildcard struct {
+	prefix string
+	suffix string
+}
+
+// match reports whether the origin matches the wildcard.
+func (w wildcard) match(origin string) bool {
+	if len(origin) <= len(w.prefix)+len(w.suffix) {
+		return false
+	}
+	if !strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+		return false
+	}
+	sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+	return !strings.ContainsAny(sub, "/:")
+}
+
+// originMatcher determines whether an origin may access the resource.
+type originMatcher struct {
+	any       bool
+	origins   map[string]struct{}
+	wildcards []wildcard
+	patterns  []*regexp.Regexp
+	fn        func(origin string, c *lungo.Context) bool
+}
+
+// newOriginMatcher creates a new originMatcher from the configuration.
+// It panics if any of the AllowOriginPatterns is not a valid regular expression.
+func newOriginMatcher(config *Config) *originMatcher {
+	m := &originMatcher{
+		origins: make(map[string]struct{}),
+		fn:      config.AllowOriginFunc,
+	}
+
+	for _, o := range config.AllowOrigins {
+		if o == "*" {
+			m.any = true
+			continue
+		}
+		if i := strings.IndexByte(o, '*'); i >= 0 {
+			m.wildcards = append(m.wildcards, wildcard{prefix: o[:i], suffix: o[i+1:]})
+			continue
+		}
+		m.origins[o] = struct{}{}
+	}
+
+	for _, p := range config.AllowOriginPatterns {
+		m.patterns = append(m.patterns, regexp.MustCompile(p))
+	}
+
+	return m
+}
+
+// match reports whether the origin may access the resource.
+func (m *originMatcher) match(origin string, c *lungo.Context) bool {
+	if _, ok := m.origins[origin]; ok {
+		return true
+	}
+	for _, w := range m.wildcards {
+		if w.match(origin) {
+			return true
+		}
+	}
+	for _, p := range m.patterns {
+		if p.MatchString(origin) {
+			return true
+		}
+	}
+	if m.fn != nil {
+		return m.fn(origin, c)
+	}
+	return false
+}
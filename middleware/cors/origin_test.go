@@ -0,0 +1,61 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	matcher := newOriginMatcher(&Config{
+		AllowOrigins:        []string{"https://example.com", "https://*.example.org"},
+		AllowOriginPatterns: []string{`^https://[a-z]+\.example\.net$`},
+		AllowOriginFunc: func(origin string, c *lungo.Context) bool {
+			return origin == "https://tenant.example.io" && c.Param("tenant") == "1"
+		},
+	})
+
+	var tests = []struct {
+		origin string
+		match  bool
+	}{
+		{origin: "https://example.com", match: true},
+		{origin: "http://example.com", match: false},
+		{origin: "https://api.example.org", match: true},
+		{origin: "https://a.b.example.org", match: true},
+		{origin: "https://.example.org", match: false},
+		{origin: "https://example.org", match: false},
+		{origin: "https://evil.com/.example.org", match: false},
+		{origin: "https://evil.com:1.example.org", match: false},
+		{origin: "https://api.example.net", match: true},
+		{origin: "https://api1.example.net", match: false},
+		{origin: "https://tenant.example.io", match: true},
+		{origin: "https://other.example.io", match: false},
+	}
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/?tenant=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	for _, testcase := range tests {
+		if matcher.match(testcase.origin, c) != testcase.match {
+			t.Errorf("Test %s: Expected origin `%s` to match: %v", t.Name(), testcase.origin, testcase.match)
+		}
+	}
+}
+
+func TestOriginMatcherInvalidPattern(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Test %s: Expected Panic for invalid pattern", t.Name())
+		}
+	}()
+	newOriginMatcher(&Config{AllowOriginPatterns: []string{"("}})
+}
@@ -0,0 +1,112 @@
+package csrf
+
+import (
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Mode defines how the CSRF token of a request is validated.
+type Mode int
+
+const (
+	// DoubleSubmitCookie stores the token inside of a cookie, which
+	// must match the token submitted with the request.
+	DoubleSubmitCookie Mode = iota
+
+	// SynchronizerToken stores the token inside of the Store, which is
+	// associated with the client by a random session ID inside of a cookie.
+	SynchronizerToken
+)
+
+// Config defines the configuration options for the CSRF middleware
+type Config struct {
+	// Mode defines how the CSRF token of a request is validated.
+	//
+	// Optional. Default value DoubleSubmitCookie.
+	Mode Mode
+
+	// TokenLength defines the number of random bytes of a token.
+	//
+	// Optional. Default value 32.
+	TokenLength int
+
+	// Lookup defines a comma-separated list of "<source>:<name>" pairs,
+	// from which the token is extracted in the given order.
+	//
+	// Possible sources:
+	// - "header:<name>"
+	// - "form:<name>"
+	// - "query:<name>"
+	//
+	// Optional. Default value "header:X-CSRF-Token,form:_csrf".
+	Lookup string
+
+	// CookieName defines the name of the cookie containing the token
+	// or the session ID, depending on the Mode.
+	//
+	// Optional. Default value "_csrf".
+	CookieName string
+
+	// CookieDomain defines the domain of the cookie.
+	//
+	// Optional. Default value "".
+	CookieDomain string
+
+	// CookiePath defines the path of the cookie.
+	//
+	// Optional. Default value "/".
+	CookiePath string
+
+	// CookieMaxAge defines the lifetime of the cookie in seconds.
+	//
+	// Optional. Default value 86400 (24 hours).
+	CookieMaxAge int
+
+	// CookieSecure defines whether or not the cookie is only sent via HTTPS.
+	//
+	// Optional. Default value false.
+	CookieSecure bool
+
+	// CookieHTTPOnly defines whether or not the cookie is inaccessible to
+	// JavaScript. In DoubleSubmitCookie mode, clients may read the token
+	// from the cookie, thus it is accessible by default. The session ID of
+	// the SynchronizerToken mode is always inaccessible to JavaScript.
+	//
+	// Optional. Default value false.
+	CookieHTTPOnly bool
+
+	// CookieSameSite defines the SameSite attribute of the cookie.
+	//
+	// Optional. Default value http.SameSiteLaxMode.
+	CookieSameSite http.SameSite
+
+	// Store defines the storage of the tokens in SynchronizerToken mode.
+	//
+	// Optional. Default value NewMemoryStore().
+	Store Store
+
+	// TrustedOrigins defines additional origins, e.g. "https://example.com",
+	// which are allowed to send unsafe requests via HTTPS. By default only
+	// requests from the same origin are allowed.
+	//
+	// Optional. Default value nil.
+	TrustedOrigins []string
+}
+
+// DefaultConfig contains the default value for the
+// CSRF middleware configuration
+var DefaultConfig = &Config{
+	Mode:           DoubleSubmitCookie,
+	TokenLength:    32,
+	Lookup:         "header:" + lungo.HeaderXCSRFToken + ",form:_csrf",
+	CookieName:     "_csrf",
+	CookieDomain:   "",
+	CookiePath:     "/",
+	CookieMaxAge:   86400,
+	CookieSecure:   false,
+	CookieHTTPOnly: false,
+	CookieSameSite: http.SameSiteLaxMode,
+	Store:          nil,
+	TrustedOrigins: nil,
+}
@@ -0,0 +1,216 @@
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// extractor extracts the token from a request.
+type extractor func(c *lungo.Context) string
+
+// New creates a new CSRF middleware instance
+//
+// Requests with a safe method (GET, HEAD, OPTIONS and TRACE) are exempt from
+// the validation of the token. Unsafe requests via HTTPS must additionally
+// originate from the same origin or one of the TrustedOrigins, which is checked
+// using the `Origin` or `Referer` header. It panics if the Lookup of the
+// configuration is invalid.
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	if config.Mode == SynchronizerToken && config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+
+	extractors := newExtractors(config.Lookup)
+
+	trusted := make(map[string]struct{}, len(config.TrustedOrigins))
+	for _, o := range config.TrustedOrigins {
+		trusted[strings.ToLower(o)] = struct{}{}
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			c.AddHeader(lungo.HeaderVary, lungo.HeaderCookie)
+
+			token, err := config.token(c)
+			if err != nil {
+				return err
+			}
+
+			// A new token is only issued once it is requested by the
+			// handler, such that requests, which don't render a form,
+			// e.g. of crawlers, neither add a token to the Store nor
+			// receive a cookie.
+			if token != "" {
+				c.Set(lungo.CSRFTokenKey, token)
+			} else {
+				c.Set(lungo.CSRFTokenKey, config.issuer())
+			}
+
+			switch c.Method() {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				return next.ServeHTTP(c)
+			}
+
+			if c.Scheme() == "https" && !sameOrigin(c, trusted) {
+				return c.Errorf(http.StatusForbidden, "Forbidden - Origin invalid")
+			}
+
+			submitted := ""
+			for _, extract := range extractors {
+				if submitted = extract(c); submitted != "" {
+					break
+				}
+			}
+
+			if token == "" || submitted == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+				return c.Errorf(http.StatusForbidden, "Forbidden - CSRF token invalid")
+			}
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// token returns the token of the client or
+// the empty string, if the client has no token yet.
+func (config *Config) token(c *lungo.Context) (string, error) {
+	cookie, err := c.Cookie(config.CookieName)
+	if err != nil || cookie.Value == "" {
+		return "", nil
+	}
+
+	if config.Mode == DoubleSubmitCookie {
+		return cookie.Value, nil
+	}
+	return config.Store.Get(cookie.Value)
+}
+
+// issuer returns a function, which issues a new token once it is called
+// for the first time and returns the same token on subsequent calls.
+// The function returns the empty string, if the token can't be issued.
+// It receives the calling Context instead of capturing it, since the
+// function may be called on a copy of the Context, see `c.Copy()`.
+func (config *Config) issuer() func(c *lungo.Context) string {
+	var (
+		mu     sync.Mutex
+		token  string
+		issued bool
+	)
+	return func(c *lungo.Context) string {
+		mu.Lock()
+		defer mu.Unlock()
+		if !issued {
+			issued = true
+			token, _ = config.issue(c)
+		}
+		return token
+	}
+}
+
+// issue generates a new token, stores it if required
+// and sets the cookie of the token on the response.
+func (config *Config) issue(c *lungo.Context) (string, error) {
+	token, err := generate(config.TokenLength)
+	if err != nil {
+		return "", err
+	}
+
+	value, httpOnly := token, config.CookieHTTPOnly
+	if config.Mode == SynchronizerToken {
+		if value, err = generate(config.TokenLength); err != nil {
+			return "", err
+		}
+		if err = config.Store.Set(value, token, time.Duration(config.CookieMaxAge)*time.Second); err != nil {
+			return "", err
+		}
+		httpOnly = true
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     config.CookieName,
+		Value:    value,
+		Domain:   config.CookieDomain,
+		Path:     config.CookiePath,
+		MaxAge:   config.CookieMaxAge,
+		Secure:   config.CookieSecure,
+		HttpOnly: httpOnly,
+		SameSite: config.CookieSameSite,
+	})
+	return token, nil
+}
+
+// sameOrigin reports whether the request originates from the same origin
+// or one of the trusted origins according to the `Origin` header or the
+// `Referer` header, if the `Origin` header is missing.
+func sameOrigin(c *lungo.Context, trusted map[string]struct{}) bool {
+	origin := c.Header(lungo.HeaderOrigin)
+	if origin == "" || origin == "null" {
+		referer, err := url.Parse(c.Header(lungo.HeaderReferer))
+		if err != nil || referer.Host == "" {
+			return false
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	origin = strings.ToLower(origin)
+	if origin == "https://"+strings.ToLower(c.Host()) {
+		return true
+	}
+	_, ok := trusted[origin]
+	return ok
+}
+
+// generate returns a base64url encoded cryptographic random token.
+func generate(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newExtractors creates the extractors of the token from the lookup.
+// It panics if the lookup contains an unknown source.
+func newExtractors(lookup string) []extractor {
+	extractors := make([]extractor, 0)
+	for _, l := range strings.Split(lookup, ",") {
+		source, name, ok := strings.Cut(strings.TrimSpace(l), ":")
+		if !ok || name == "" {
+			panic(fmt.Sprintf("Invalid lookup. The CSRF token lookup `%s` must be of the form `<source>:<name>`.", l))
+		}
+
+		switch source {
+		case "header":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				return c.Header(name)
+			})
+		case "form":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				return c.Request.PostFormValue(name)
+			})
+		case "query":
+			extractors = append(extractors, func(c *lungo.Context) string {
+				return c.Request.URL.Query().Get(name)
+			})
+		default:
+			panic(fmt.Sprintf("Invalid lookup. The CSRF token source `%s` is unknown.", source))
+		}
+	}
+	return extractors
+}
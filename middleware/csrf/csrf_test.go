@@ -0,0 +1,261 @@
+package csrf
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+// serve dispatches the request to the middleware and returns the
+// response recorder and the token passed to the handler.
+func serve(t *testing.T, middleware lungo.Middleware, req *http.Request) (*httptest.ResponseRecorder, string) {
+	rr := httptest.NewRecorder()
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	token := ""
+	h := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+		token = c.CSRFToken()
+		return c.NoContent()
+	}))
+	if err := h.ServeHTTP(c); err != nil {
+		app.HandleError(c, err)
+	}
+	return rr, token
+}
+
+func TestCSRFDoubleSubmitCookie(t *testing.T) {
+	middleware := New()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr, token := serve(t, middleware, req)
+	assertEqual(t, http.StatusNoContent, rr.Code)
+	assertEqual(t, 43, len(token))
+
+	cookies := rr.Result().Cookies()
+	assertEqual(t, 1, len(cookies))
+	assertEqual(t, "_csrf", cookies[0].Name)
+	assertEqual(t, token, cookies[0].Value)
+	assertEqual(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	assertEqual(t, "Cookie", rr.Header().Get(lungo.HeaderVary))
+
+	var tests = []struct {
+		request func() *http.Request
+		code    int
+	}{
+		{
+			request: func() *http.Request {
+				return httptest.NewRequest("POST", "/", nil)
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			request: func() *http.Request {
+				req := httptest.NewRequest("POST", "/", nil)
+				req.AddCookie(cookies[0])
+				return req
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			request: func() *http.Request {
+				req := httptest.NewRequest("POST", "/", nil)
+				req.AddCookie(cookies[0])
+				req.Header.Set(lungo.HeaderXCSRFToken, "invalid")
+				return req
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			request: func() *http.Request {
+				req := httptest.NewRequest("POST", "/", nil)
+				req.AddCookie(cookies[0])
+				req.Header.Set(lungo.HeaderXCSRFToken, token)
+				return req
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			request: func() *http.Request {
+				form := url.Values{"_csrf": {token}}
+				req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+				req.Header.Set(lungo.HeaderContentType, lungo.MIMEApplicationForm)
+				req.AddCookie(cookies[0])
+				return req
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			request: func() *http.Request {
+				req := httptest.NewRequest("DELETE", "/?_csrf="+token, nil)
+				req.AddCookie(cookies[0])
+				return req
+			},
+			code: http.StatusForbidden,
+		},
+	}
+
+	for _, testcase := range tests {
+		rr, _ := serve(t, middleware, testcase.request())
+		assertEqual(t, testcase.code, rr.Code)
+	}
+}
+
+func TestCSRFSynchronizerToken(t *testing.T) {
+	store := NewMemoryStore()
+	middleware := New(func(c *Config) {
+		c.Mode = SynchronizerToken
+		c.Store = store
+		c.Lookup = "query:_csrf"
+	})
+
+	rr, token := serve(t, middleware, httptest.NewRequest("GET", "/", nil))
+	assertEqual(t, http.StatusNoContent, rr.Code)
+	assertEqual(t, 1, store.Len())
+
+	cookies := rr.Result().Cookies()
+	assertEqual(t, 1, len(cookies))
+	assertEqual(t, true, cookies[0].HttpOnly)
+	assertEqual(t, false, token == cookies[0].Value)
+
+	// The token of the session is kept across requests.
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(cookies[0])
+	rr, next := serve(t, middleware, req)
+	assertEqual(t, token, next)
+	assertEqual(t, 0, len(rr.Result().Cookies()))
+
+	req = httptest.NewRequest("POST", "/?_csrf="+token, nil)
+	req.AddCookie(cookies[0])
+	rr, _ = serve(t, middleware, req)
+	assertEqual(t, http.StatusNoContent, rr.Code)
+
+	// The session ID itself is not a valid token.
+	req = httptest.NewRequest("POST", "/?_csrf="+cookies[0].Value, nil)
+	req.AddCookie(cookies[0])
+	rr, _ = serve(t, middleware, req)
+	assertEqual(t, http.StatusForbidden, rr.Code)
+
+	// An unknown session ID is rejected without creating a new session.
+	req = httptest.NewRequest("POST", "/?_csrf="+token, nil)
+	req.AddCookie(&http.Cookie{Name: "_csrf", Value: "unknown"})
+	rr, _ = serve(t, middleware, req)
+	assertEqual(t, http.StatusForbidden, rr.Code)
+	assertEqual(t, 0, len(rr.Result().Cookies()))
+	assertEqual(t, 1, store.Len())
+}
+
+func TestCSRFLazyToken(t *testing.T) {
+	store := NewMemoryStore()
+	middleware := New(func(c *Config) {
+		c.Mode = SynchronizerToken
+		c.Store = store
+	})
+
+	// Requests, which don't request the token, are not issued a token.
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		c := lungo.New().NewContext(rr, httptest.NewRequest("GET", "/", nil))
+		err := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			return c.NoContent()
+		})).ServeHTTP(c)
+		assertEqual(t, nil, err)
+		assertEqual(t, 0, len(rr.Result().Cookies()))
+	}
+	assertEqual(t, 0, store.Len())
+
+	// The token is issued once, even if it is requested multiple times.
+	rr := httptest.NewRecorder()
+	c := lungo.New().NewContext(rr, httptest.NewRequest("GET", "/", nil))
+	err := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+		assertEqual(t, c.CSRFToken(), c.CSRFToken())
+		return c.NoContent()
+	})).ServeHTTP(c)
+	assertEqual(t, nil, err)
+	assertEqual(t, 1, len(rr.Result().Cookies()))
+	assertEqual(t, 1, store.Len())
+
+	// The token is issued on the Context, on which it is requested,
+	// even if the original Context was already reset, see `c.Copy()`.
+	rr = httptest.NewRecorder()
+	copied := httptest.NewRecorder()
+	c = lungo.New().NewContext(rr, httptest.NewRequest("GET", "/", nil))
+	err = middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+		cc := c.Copy()
+		cc.Response = copied
+		c.Reset(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+		assertEqual(t, true, cc.CSRFToken() != "")
+		return nil
+	})).ServeHTTP(c)
+	assertEqual(t, nil, err)
+	assertEqual(t, 0, len(rr.Result().Cookies()))
+	assertEqual(t, 1, len(copied.Result().Cookies()))
+	assertEqual(t, 2, store.Len())
+}
+
+func TestCSRFOrigin(t *testing.T) {
+	middleware := New(func(c *Config) {
+		c.TrustedOrigins = []string{"https://trusted.com"}
+	})
+
+	var tests = []struct {
+		origin  string
+		referer string
+		code    int
+	}{
+		{code: http.StatusForbidden},
+		{origin: "https://example.com", code: http.StatusNoContent},
+		{origin: "https://EXAMPLE.com", code: http.StatusNoContent},
+		{origin: "http://example.com", code: http.StatusForbidden},
+		{origin: "https://evil.com", code: http.StatusForbidden},
+		{origin: "https://trusted.com", code: http.StatusNoContent},
+		{origin: "null", code: http.StatusForbidden},
+		{referer: "https://example.com/form", code: http.StatusNoContent},
+		{referer: "https://evil.com/form", code: http.StatusForbidden},
+		{origin: "https://evil.com", referer: "https://example.com/form", code: http.StatusForbidden},
+	}
+
+	for _, testcase := range tests {
+		req := httptest.NewRequest("POST", "https://example.com/", nil)
+		req.TLS = &tls.ConnectionState{}
+		req.AddCookie(&http.Cookie{Name: "_csrf", Value: "token"})
+		req.Header.Set(lungo.HeaderXCSRFToken, "token")
+		if testcase.origin != "" {
+			req.Header.Set(lungo.HeaderOrigin, testcase.origin)
+		}
+		if testcase.referer != "" {
+			req.Header.Set(lungo.HeaderReferer, testcase.referer)
+		}
+
+		rr, _ := serve(t, middleware, req)
+		assertEqual(t, testcase.code, rr.Code)
+	}
+}
+
+func TestCSRFInvalidLookup(t *testing.T) {
+	for _, lookup := range []string{"header", "form:", "cookie:_csrf"} {
+		func() {
+			defer func() {
+				assertEqual(t, true, recover() != nil)
+			}()
+			New(func(c *Config) {
+				c.Lookup = lookup
+			})
+		}()
+	}
+}
@@ -0,0 +1,75 @@
+package csrf
+
+import (
+	"sync"
+	"time"
+)
+
+// Store defines the interface of the storage of
+// the tokens in SynchronizerToken mode.
+type Store interface {
+	// Get returns the token associated with the session ID
+	// or the empty string if no token is found.
+	Get(id string) (string, error)
+
+	// Set associates the token with the session ID for the duration of ttl.
+	Set(id, token string, ttl time.Duration) error
+}
+
+// entry is a token stored in the MemoryStore.
+type entry struct {
+	token   string
+	expires time.Time
+}
+
+// MemoryStore is an in-memory Store. Expired tokens are removed lazily.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	swept   time.Time
+	now     func() time.Time
+}
+
+// NewMemoryStore creates a new in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]entry), now: time.Now}
+}
+
+// Get implements the Store interface.
+func (s *MemoryStore) Get(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok || !s.now().Before(e.expires) {
+		return "", nil
+	}
+	return e.token, nil
+}
+
+// Set implements the Store interface.
+func (s *MemoryStore) Set(id, token string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.entries[id] = entry{token: token, expires: now.Add(ttl)}
+
+	// Remove expired tokens at most once per minute.
+	if now.Sub(s.swept) >= time.Minute {
+		s.swept = now
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of tokens in the store, including expired tokens.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,31 @@
+package csrf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	token, err := s.Get("foo")
+	assertEqual(t, nil, err)
+	assertEqual(t, "", token)
+
+	assertEqual(t, nil, s.Set("foo", "token", time.Minute))
+	token, err = s.Get("foo")
+	assertEqual(t, nil, err)
+	assertEqual(t, "token", token)
+
+	now = now.Add(time.Minute)
+	token, err = s.Get("foo")
+	assertEqual(t, nil, err)
+	assertEqual(t, "", token)
+	assertEqual(t, 1, s.Len())
+
+	// Expired tokens are removed on the next sweep.
+	assertEqual(t, nil, s.Set("bar", "token", time.Minute))
+	assertEqual(t, 1, s.Len())
+}
@@ -0,0 +1,21 @@
+package decompress
+
+// Config defines the configuration options for the decompress middleware
+type Config struct {
+	// MaxBodySize defines the maximum number of bytes to read from the
+	// decompressed request body. A larger request body results in a
+	// "http: request body too large" error, which protects against
+	// decompression bombs.
+	//
+	// Set this value to -1 to allow all arbitrary large request bodies.
+	// Set this value to 0 to use the MaxBodySize of the application.
+	//
+	// Optional. Default: 0
+	MaxBodySize int
+}
+
+// DefaultConfig contains the default value for the
+// decompress middleware configuration
+var DefaultConfig = &Config{
+	MaxBodySize: 0,
+}
@@ -0,0 +1,85 @@
+package decompress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// reader is the decompressed request body. Closing
+// it closes the decoder and the original body.
+type reader struct {
+	io.Reader
+	decoder io.Closer
+	body    io.Closer
+}
+
+// Close implements the io.Closer interface.
+func (r *reader) Close() error {
+	derr := r.decoder.Close()
+	if err := r.body.Close(); err != nil {
+		return err
+	}
+	return derr
+}
+
+// New creates a new decompress middleware instance
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			encoding := strings.ToLower(strings.TrimSpace(c.Header(lungo.HeaderContentEncoding)))
+			if encoding == "" || encoding == "identity" || c.Request.Body == nil || c.Request.Body == http.NoBody {
+				return next.ServeHTTP(c)
+			}
+
+			var decoder io.ReadCloser
+			var err error
+			switch encoding {
+			case "gzip", "x-gzip":
+				decoder, err = gzip.NewReader(c.Request.Body)
+			case "deflate":
+				decoder, err = zlib.NewReader(c.Request.Body)
+			default:
+				msg := fmt.Sprintf("Request header for `%s` contains unsupported encoding `%s`", lungo.HeaderContentEncoding, encoding)
+				return &lungo.RequestError{Code: http.StatusUnsupportedMediaType, Message: msg}
+			}
+			if err != nil {
+				msg := fmt.Sprintf("Request body is not encoded as `%s`", encoding)
+				return &lungo.RequestError{Code: http.StatusBadRequest, Message: msg}
+			}
+
+			var r io.ReadCloser = &reader{Reader: decoder, decoder: decoder, body: c.Request.Body}
+
+			// Use http.MaxBytesReader to limit the size of the decompressed
+			// body. A request body larger than that will result in a
+			// "http: request body too large" error when reading.
+			max := config.MaxBodySize
+			if max == 0 && c.App != nil {
+				max = c.App.Config().MaxBodySize
+			}
+			if max > -1 {
+				r = http.MaxBytesReader(c.Response, r, int64(max))
+			}
+
+			// The length of the decompressed body is unknown.
+			c.Request.Body = r
+			c.Request.ContentLength = -1
+			c.Request.Header.Del(lungo.HeaderContentEncoding)
+			c.Request.Header.Del(lungo.HeaderContentLength)
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package decompress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func encode(t *testing.T, encoding, s string) []byte {
+	var b bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&b)
+	case "deflate":
+		w = zlib.NewWriter(&b)
+	default:
+		return []byte(s)
+	}
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	msg := `{"msg":"Hello, world!"}`
+	bomb := `{"msg":"` + strings.Repeat("0", 2*lungo.DefaultMaxBodySize) + `"}`
+
+	var tests = []struct {
+		encoding   string
+		body       []byte
+		configure  func(*lungo.Config)
+		middleware lungo.Middleware
+		eval       func(err error)
+	}{
+		{
+			encoding: "",
+			body:     encode(t, "", msg),
+			eval: func(err error) {
+				assertEqual(t, nil, err)
+			},
+		},
+		{
+			encoding: "gzip",
+			body:     encode(t, "gzip", msg),
+			eval: func(err error) {
+				assertEqual(t, nil, err)
+			},
+		},
+		{
+			encoding: "x-gzip",
+			body:     encode(t, "gzip", msg),
+			eval: func(err error) {
+				assertEqual(t, nil, err)
+			},
+		},
+		{
+			encoding: "deflate",
+			body:     encode(t, "deflate", msg),
+			eval: func(err error) {
+				assertEqual(t, nil, err)
+			},
+		},
+		{
+			encoding: "br",
+			body:     encode(t, "", msg),
+			eval: func(err error) {
+				re, ok := err.(*lungo.RequestError)
+				assertEqual(t, true, ok)
+				assertEqual(t, http.StatusUnsupportedMediaType, re.Code)
+			},
+		},
+		{
+			encoding: "gzip",
+			body:     encode(t, "", msg),
+			eval: func(err error) {
+				re, ok := err.(*lungo.RequestError)
+				assertEqual(t, true, ok)
+				assertEqual(t, http.StatusBadRequest, re.Code)
+			},
+		},
+		{
+			encoding: "gzip",
+			body:     encode(t, "gzip", bomb),
+			eval: func(err error) {
+				re, ok := err.(*lungo.RequestError)
+				assertEqual(t, true, ok)
+				assertEqual(t, http.StatusRequestEntityTooLarge, re.Code)
+			},
+		},
+		{
+			encoding: "gzip",
+			body:     encode(t, "gzip", msg),
+			middleware: New(func(c *Config) {
+				c.MaxBodySize = 10
+			}),
+			eval: func(err error) {
+				_, ok := err.(*lungo.RequestError)
+				assertEqual(t, true, ok)
+			},
+		},
+		{
+			encoding: "gzip",
+			body:     encode(t, "gzip", bomb),
+			configure: func(c *lungo.Config) {
+				c.MaxBodySize = -1
+			},
+			eval: func(err error) {
+				assertEqual(t, nil, err)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(testcase.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(lungo.HeaderContentType, lungo.MIMEApplicationJSON)
+		req.Header.Set(lungo.HeaderContentEncoding, testcase.encoding)
+
+		configure := testcase.configure
+		if configure == nil {
+			configure = func(c *lungo.Config) {}
+		}
+
+		app := lungo.New(configure)
+		c := app.NewContext(rr, req)
+
+		middleware := testcase.middleware
+		if middleware == nil {
+			middleware = New()
+		}
+
+		h := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			assertEqual(t, "", c.Header(lungo.HeaderContentEncoding))
+
+			var dst struct {
+				Msg string `json:"msg"`
+			}
+			if err := c.DecodeJSONBody(&dst); err != nil {
+				return err
+			}
+			if len(dst.Msg) < 100 {
+				assertEqual(t, "Hello, world!", dst.Msg)
+			}
+			return c.Request.Body.Close()
+		}))
+
+		testcase.eval(h.ServeHTTP(c))
+	}
+}
@@ -0,0 +1,44 @@
+package etag
+
+import (
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the etag middleware
+type Config struct {
+	// Weak defines whether or not weak ETags are generated. A weak ETag
+	// indicates that the response is semantically equivalent, but not
+	// necessarily byte-for-byte identical, e.g. when compressing responses.
+	//
+	// see: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	//
+	// Optional. Default value false.
+	Weak bool
+
+	// MaxSize defines the maximum size (in bytes) of a response body to
+	// be buffered in order to generate an ETag. Larger responses are
+	// written through without an ETag, unless the handler sets one.
+	//
+	// Optional. Default value 1048576 (1 MiB).
+	MaxSize int
+
+	// Validator defines a function which returns the current ETag and
+	// modification time of the requested resource. It is used to evaluate
+	// the `If-Match`, `If-None-Match` and `If-Unmodified-Since` preconditions
+	// of unsafe requests (e.g. PUT or DELETE) before calling the handler.
+	// An empty ETag indicates that the resource does not exist.
+	// Preconditions of unsafe requests are ignored, if no Validator is set.
+	//
+	// Optional. Default value nil.
+	Validator func(c *lungo.Context) (etag string, lastModified time.Time, err error)
+}
+
+// DefaultConfig contains the default value for the
+// etag middleware configuration
+var DefaultConfig = &Config{
+	Weak:      false,
+	MaxSize:   1 << 20,
+	Validator: nil,
+}
@@ -0,0 +1,109 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// New creates a new etag middleware instance
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			if c.Method() != http.MethodGet && c.Method() != http.MethodHead {
+				return precondition(c, next, config)
+			}
+
+			w := &writer{ResponseWriter: c.Response, maxSize: config.MaxSize}
+			c.Response = w
+			err := next.ServeHTTP(c)
+			c.Response = w.ResponseWriter
+
+			// The buffered response is discarded on error,
+			// such that it is replaced by the error response.
+			if w.flushed || err != nil {
+				return err
+			}
+
+			// Responses other than 200 OK are always flushed,
+			// thus the buffered response is a 200 OK.
+			h := c.Response.Header()
+			etag := h.Get(lungo.HeaderETag)
+
+			// A HEAD handler may omit the body, in which case the
+			// ETag of the empty body would not match the one of GET.
+			if etag == "" && (c.Method() != http.MethodHead || w.buf.Len() > 0) {
+				etag = generate(w.buf.Bytes(), config.Weak)
+				h.Set(lungo.HeaderETag, etag)
+			}
+
+			if etag != "" {
+				var lastModified time.Time
+				if lm := h.Get(lungo.HeaderLastModified); lm != "" {
+					lastModified, _ = http.ParseTime(lm)
+				}
+
+				switch evaluate(c.Request, etag, lastModified) {
+				case http.StatusNotModified:
+					h.Del(lungo.HeaderContentType)
+					h.Del(lungo.HeaderContentLength)
+					c.WriteHeader(http.StatusNotModified)
+					return err
+				case http.StatusPreconditionFailed:
+					h.Del(lungo.HeaderETag)
+					return c.Error(http.StatusPreconditionFailed)
+				}
+			}
+
+			c.WriteHeader(http.StatusOK)
+			if _, werr := c.Response.Write(w.buf.Bytes()); err == nil {
+				err = werr
+			}
+			return err
+		})
+	}
+}
+
+// precondition evaluates the preconditions of an unsafe request
+// using the Validator before dispatching it to the handler.
+func precondition(c *lungo.Context, next lungo.Handler, config *Config) error {
+	if config.Validator == nil {
+		return next.ServeHTTP(c)
+	}
+
+	h := c.Request.Header
+	if h.Get(lungo.HeaderIfMatch) == "" && h.Get(lungo.HeaderIfNoneMatch) == "" && h.Get(lungo.HeaderIfUnmodifiedSince) == "" {
+		return next.ServeHTTP(c)
+	}
+
+	etag, lastModified, err := config.Validator(c)
+	if err != nil {
+		return err
+	}
+
+	if status := evaluate(c.Request, etag, lastModified); status != 0 {
+		return c.Error(status)
+	}
+
+	return next.ServeHTTP(c)
+}
+
+// generate returns an ETag for the body.
+func generate(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf("\"%x\"", sum[:16])
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
@@ -0,0 +1,367 @@
+package etag
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func TestETag(t *testing.T) {
+	body := "Hello, world!"
+	strong := generate([]byte(body), false)
+	weak := generate([]byte(body), true)
+	modified := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		method       string
+		header       map[string]string
+		lastModified time.Time
+		middleware   lungo.Middleware
+		status       int
+		etag         string
+		body         string
+	}{
+		{status: http.StatusOK, etag: strong, body: body},
+		{method: http.MethodHead, status: http.StatusOK, etag: strong, body: body},
+		{middleware: New(func(c *Config) { c.Weak = true }), status: http.StatusOK, etag: weak, body: body},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: strong}, status: http.StatusNotModified, etag: strong},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: `"foo", ` + weak}, status: http.StatusNotModified, etag: strong},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: "*"}, status: http.StatusNotModified, etag: strong},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: `"foo"`}, status: http.StatusOK, etag: strong, body: body},
+		{header: map[string]string{lungo.HeaderIfMatch: strong}, status: http.StatusOK, etag: strong, body: body},
+		{header: map[string]string{lungo.HeaderIfMatch: weak}, status: http.StatusPreconditionFailed},
+		{header: map[string]string{lungo.HeaderIfMatch: `"foo"`}, status: http.StatusPreconditionFailed},
+		{
+			header:       map[string]string{lungo.HeaderIfModifiedSince: modified.Format(http.TimeFormat)},
+			lastModified: modified,
+			status:       http.StatusNotModified,
+			etag:         strong,
+		},
+		{
+			header:       map[string]string{lungo.HeaderIfModifiedSince: modified.Add(-time.Hour).Format(http.TimeFormat)},
+			lastModified: modified,
+			status:       http.StatusOK,
+			etag:         strong,
+			body:         body,
+		},
+		{
+			header:       map[string]string{lungo.HeaderIfUnmodifiedSince: modified.Add(-time.Hour).Format(http.TimeFormat)},
+			lastModified: modified,
+			status:       http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, testcase := range tests {
+		method := testcase.method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(method, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		middleware := testcase.middleware
+		if middleware == nil {
+			middleware = New()
+		}
+
+		h := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			if !testcase.lastModified.IsZero() {
+				c.SetHeader(lungo.HeaderLastModified, testcase.lastModified.Format(http.TimeFormat))
+			}
+			return c.Text(http.StatusOK, body)
+		}))
+
+		err = h.ServeHTTP(c)
+		if re, ok := err.(*lungo.RequestError); ok {
+			rr.Code = re.Code
+		}
+
+		assertEqual(t, testcase.status, rr.Code)
+		assertEqual(t, testcase.etag, rr.Header().Get(lungo.HeaderETag))
+		assertEqual(t, testcase.body, rr.Body.String())
+	}
+}
+
+func TestETagHandler(t *testing.T) {
+	var tests = []struct {
+		handler lungo.HandlerFunc
+		eval    func(rr *httptest.ResponseRecorder, err error)
+	}{
+		{
+			handler: func(c *lungo.Context) error {
+				return c.NotFound()
+			},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, true, err != nil)
+				assertEqual(t, "", rr.Header().Get(lungo.HeaderETag))
+			},
+		},
+		{
+			handler: func(c *lungo.Context) error {
+				c.Text(http.StatusOK, "Hello, world!")
+				return errors.New("Error")
+			},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, "Error", err.Error())
+				assertEqual(t, false, rr.Flushed)
+				assertEqual(t, "", rr.Header().Get(lungo.HeaderETag))
+				assertEqual(t, "", rr.Body.String())
+			},
+		},
+		{
+			handler: func(c *lungo.Context) error {
+				return c.Text(http.StatusCreated, "Created")
+			},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, nil, err)
+				assertEqual(t, http.StatusCreated, rr.Code)
+				assertEqual(t, "", rr.Header().Get(lungo.HeaderETag))
+				assertEqual(t, "Created", rr.Body.String())
+			},
+		},
+		{
+			handler: func(c *lungo.Context) error {
+				c.SetHeader(lungo.HeaderETag, `"v1"`)
+				return c.Text(http.StatusOK, "Hello, world!")
+			},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, nil, err)
+				assertEqual(t, `"v1"`, rr.Header().Get(lungo.HeaderETag))
+			},
+		},
+		{
+			handler: func(c *lungo.Context) error {
+				c.Text(http.StatusOK, "Hello, ")
+				c.Flush()
+				_, err := c.Response.Write([]byte("world!"))
+				return err
+			},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, nil, err)
+				assertEqual(t, true, rr.Flushed)
+				assertEqual(t, "", rr.Header().Get(lungo.HeaderETag))
+				assertEqual(t, "Hello, world!", rr.Body.String())
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		err = New()(testcase.handler).ServeHTTP(c)
+		testcase.eval(rr, err)
+	}
+}
+
+func TestETagPassThrough(t *testing.T) {
+	modified := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	content := func(c *lungo.Context) error {
+		http.ServeContent(c.Response, c.Request, "hello.txt", modified, strings.NewReader("Hello, world!"))
+		return nil
+	}
+
+	var tests = []struct {
+		method     string
+		header     map[string]string
+		middleware lungo.Middleware
+		handler    lungo.HandlerFunc
+		status     int
+		etag       string
+		body       string
+	}{
+		{
+			middleware: New(func(c *Config) { c.MaxSize = 5 }),
+			handler:    content,
+			status:     http.StatusOK,
+			body:       "Hello, world!",
+		},
+		{
+			header:  map[string]string{lungo.HeaderRange: "bytes=0-4"},
+			handler: content,
+			status:  http.StatusPartialContent,
+			body:    "Hello",
+		},
+		{
+			method:  http.MethodHead,
+			handler: content,
+			status:  http.StatusOK,
+		},
+		{
+			method: http.MethodHead,
+			handler: func(c *lungo.Context) error {
+				c.WriteHeader(http.StatusOK)
+				return nil
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodHead,
+			handler: func(c *lungo.Context) error {
+				return c.Text(http.StatusOK, "Hello, world!")
+			},
+			status: http.StatusOK,
+			etag:   generate([]byte("Hello, world!"), false),
+			body:   "Hello, world!",
+		},
+	}
+
+	for _, testcase := range tests {
+		method := testcase.method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(method, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
+		c := lungo.New().NewContext(rr, req)
+
+		middleware := testcase.middleware
+		if middleware == nil {
+			middleware = New()
+		}
+
+		err = middleware(testcase.handler).ServeHTTP(c)
+
+		assertEqual(t, nil, err)
+		assertEqual(t, testcase.status, rr.Code)
+		assertEqual(t, testcase.etag, rr.Header().Get(lungo.HeaderETag))
+		assertEqual(t, testcase.body, rr.Body.String())
+	}
+}
+
+type hijacker struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (h *hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	return nil, nil, nil
+}
+
+func TestETagHijack(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := &hijacker{ResponseRecorder: httptest.NewRecorder()}
+	c := lungo.New().NewContext(rr, req)
+
+	err = New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		_, _, err := c.Hijack()
+		return err
+	})).ServeHTTP(c)
+
+	assertEqual(t, nil, err)
+	assertEqual(t, true, rr.hijacked)
+	assertEqual(t, "", rr.Body.String())
+	assertEqual(t, "", rr.Header().Get(lungo.HeaderETag))
+}
+
+func TestETagPrecondition(t *testing.T) {
+	modified := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		header    map[string]string
+		validator func(c *lungo.Context) (string, time.Time, error)
+		status    int
+		err       error
+	}{
+		{header: map[string]string{lungo.HeaderIfMatch: `"v1"`}, status: http.StatusNoContent},
+		{header: map[string]string{lungo.HeaderIfMatch: `"v1"`}, validator: current(`"v1"`, modified), status: http.StatusNoContent},
+		{header: map[string]string{lungo.HeaderIfMatch: `"v0"`}, validator: current(`"v1"`, modified), status: http.StatusPreconditionFailed},
+		{header: map[string]string{lungo.HeaderIfMatch: `W/"v1"`}, validator: current(`W/"v1"`, modified), status: http.StatusPreconditionFailed},
+		{header: map[string]string{lungo.HeaderIfMatch: "*"}, validator: current(`"v1"`, modified), status: http.StatusNoContent},
+		{header: map[string]string{lungo.HeaderIfMatch: "*"}, validator: current("", time.Time{}), status: http.StatusPreconditionFailed},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: "*"}, validator: current("", time.Time{}), status: http.StatusNoContent},
+		{header: map[string]string{lungo.HeaderIfNoneMatch: "*"}, validator: current(`"v1"`, modified), status: http.StatusPreconditionFailed},
+		{header: map[string]string{lungo.HeaderIfUnmodifiedSince: modified.Format(http.TimeFormat)}, validator: current(`"v1"`, modified), status: http.StatusNoContent},
+		{header: map[string]string{lungo.HeaderIfUnmodifiedSince: modified.Add(-time.Hour).Format(http.TimeFormat)}, validator: current(`"v1"`, modified), status: http.StatusPreconditionFailed},
+		{header: map[string]string{}, validator: current(`"v1"`, modified), status: http.StatusNoContent},
+		{
+			header: map[string]string{lungo.HeaderIfMatch: `"v1"`},
+			validator: func(c *lungo.Context) (string, time.Time, error) {
+				return "", time.Time{}, errors.New("Validator: failed")
+			},
+			err: errors.New("Validator: failed"),
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPut, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testcase.header {
+			req.Header.Set(k, v)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		h := New(func(c *Config) {
+			c.Validator = testcase.validator
+		})(lungo.HandlerFunc(func(c *lungo.Context) error {
+			return c.NoContent()
+		}))
+
+		err = h.ServeHTTP(c)
+		if testcase.err != nil {
+			assertEqual(t, testcase.err, err)
+			continue
+		}
+		if re, ok := err.(*lungo.RequestError); ok {
+			rr.Code = re.Code
+		}
+
+		assertEqual(t, testcase.status, rr.Code)
+	}
+}
+
+func current(etag string, lastModified time.Time) func(c *lungo.Context) (string, time.Time, error) {
+	return func(c *lungo.Context) (string, time.Time, error) {
+		return etag, lastModified, nil
+	}
+}
@@ -0,0 +1,71 @@
+package etag
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// evaluate evaluates the preconditions of the request against the current
+// ETag and modification time of the resource according to RFC 7232, Section 6.
+// It returns the status code to reply with or 0 if the request may proceed.
+func evaluate(r *http.Request, etag string, lastModified time.Time) int {
+	safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	if im := r.Header.Get(lungo.HeaderIfMatch); im != "" {
+		if !match(im, etag, true) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ius := r.Header.Get(lungo.HeaderIfUnmodifiedSince); ius != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ius); err == nil && lastModified.Truncate(time.Second).After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	if inm := r.Header.Get(lungo.HeaderIfNoneMatch); inm != "" {
+		if match(inm, etag, false) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if ims := r.Header.Get(lungo.HeaderIfModifiedSince); ims != "" && safe && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+// match reports whether the ETag matches any entry of the list
+// of an `If-Match` or `If-None-Match` header. An empty ETag
+// indicates that the resource does not exist.
+func match(list, etag string, strong bool) bool {
+	if etag == "" {
+		return false
+	}
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "*" {
+			return true
+		}
+		if strong {
+			if !weak(s) && !weak(etag) && s == etag {
+				return true
+			}
+			continue
+		}
+		if strings.TrimPrefix(s, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// weak reports whether the ETag is weak.
+func weak(etag string) bool {
+	return strings.HasPrefix(etag, "W/")
+}
@@ -0,0 +1,82 @@
+package etag
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// writer is a http.ResponseWriter, which buffers the response
+// in order to generate an ETag from the body. Flushing the
+// response writes the buffer and disables buffering. Responses
+// other than 200 OK or exceeding maxSize are written through.
+type writer struct {
+	http.ResponseWriter
+	buf     bytes.Buffer
+	code    int
+	maxSize int
+	flushed bool
+}
+
+// WriteHeader records the status code of the response.
+func (w *writer) WriteHeader(code int) {
+	if w.flushed {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.code == 0 {
+		w.code = code
+		if code != http.StatusOK {
+			w.flush()
+		}
+	}
+}
+
+// Write implements the io.Writer interface.
+func (w *writer) Write(b []byte) (int, error) {
+	if !w.flushed && w.buf.Len()+len(b) > w.maxSize {
+		w.flush()
+	}
+	if w.flushed {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+// Flush implements the http.Flusher interface.
+func (w *writer) Flush() {
+	w.flush()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// flush writes the status code and the buffer to the
+// underlying http.ResponseWriter and disables buffering.
+func (w *writer) flush() {
+	if w.flushed {
+		return
+	}
+	w.flushed = true
+	if w.code != 0 {
+		w.ResponseWriter.WriteHeader(w.code)
+	}
+	if w.buf.Len() > 0 {
+		w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
+
+// Hijack implements the http.Hijacker interface.
+// The buffer is discarded and buffering is disabled,
+// since the connection is taken over by the handler.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.flushed = true
+		w.buf.Reset()
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("Hijack: response does not implement http.Hijacker")
+}
@@ -0,0 +1,79 @@
+package jwt
+
+import (
+	"time"
+)
+
+// Config defines the configuration options for the JWT middleware
+type Config struct {
+	// Keys defines a static set of keys to verify the signature of tokens.
+	//
+	// Optional. Default value nil.
+	Keys []Key
+
+	// JWKSFile defines the path of a local JSON Web Key Set file containing
+	// the keys to verify the signature of tokens. The file is reloaded once it
+	// was modified, such that keys can be rotated without a restart.
+	//
+	// see: https://datatracker.ietf.org/doc/html/rfc7517#section-5
+	//
+	// Optional. Default value "".
+	JWKSFile string
+
+	// JWKSRefresh defines the interval in which the JWKSFile is checked
+	// for modifications. Additionally the file is checked whenever a
+	// token references an unknown key ID, see JWKSMinRefresh.
+	//
+	// Optional. Default value 1 * time.Minute.
+	JWKSRefresh time.Duration
+
+	// JWKSMinRefresh defines the minimum interval between the checks of
+	// the JWKSFile, which are triggered by tokens referencing an unknown
+	// key ID. It limits the checks caused by tokens with random key IDs.
+	//
+	// Optional. Default value 10 * time.Second.
+	JWKSMinRefresh time.Duration
+
+	// Algorithms defines the allowed signing algorithms of tokens.
+	//
+	// Optional. Default value []string{HS256, RS256, ES256, EdDSA}.
+	Algorithms []string
+
+	// Issuer defines the expected issuer ("iss" claim) of tokens.
+	// The issuer is not validated, if empty.
+	//
+	// Optional. Default value "".
+	Issuer string
+
+	// Audience defines the expected audience ("aud" claim) of tokens.
+	// The audience is not validated, if empty.
+	//
+	// Optional. Default value "".
+	Audience string
+
+	// ClockSkew defines the tolerated difference between the clocks of the
+	// issuer and the server when validating the "exp" and "nbf" claims.
+	//
+	// Optional. Default value 1 * time.Minute.
+	ClockSkew time.Duration
+
+	// Realm defines the protection space of the token,
+	// which is sent inside of the `WWW-Authenticate` header.
+	//
+	// Optional. Default value "Restricted".
+	Realm string
+}
+
+// DefaultConfig contains the default value for the
+// JWT middleware configuration
+var DefaultConfig = &Config{
+	Keys:           nil,
+	JWKSFile:       "",
+	JWKSRefresh:    1 * time.Minute,
+	JWKSMinRefresh: 10 * time.Second,
+	Algorithms:     []string{HS256, RS256, ES256, EdDSA},
+	Issuer:         "",
+	Audience:       "",
+	ClockSkew:      1 * time.Minute,
+	Realm:          "Restricted",
+}
@@ -0,0 +1,250 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// jwk represents a JSON Web Key.
+//
+// see: https://datatracker.ietf.org/doc/html/rfc7517#section-4
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS parses the keys of a JSON Web Key Set. Keys of unsupported
+// types or curves and keys intended for encryption are skipped.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS: key %q: %w", k.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+
+		keys = append(keys, Key{ID: k.Kid, Algorithm: k.Alg, Key: key})
+	}
+	return keys, nil
+}
+
+// parse returns the key material of the JSON Web Key
+// or nil, if the type of the key is not supported.
+func (k jwk) parse() (any, error) {
+	switch k.Kty {
+	case "oct":
+		secret, err := decodeSegment(k.K)
+		if err != nil || len(secret) == 0 {
+			return nil, errors.New("invalid symmetric key")
+		}
+		return secret, nil
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.New("invalid RSA modulus")
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil || len(x) != 32 {
+			return nil, errors.New("invalid EC x coordinate")
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil || len(y) != 32 {
+			return nil, errors.New("invalid EC y coordinate")
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return pub, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, nil
+}
+
+// keySet contains the static keys and the keys of a JWKS file,
+// which is reloaded once it was modified.
+type keySet struct {
+	static     []Key
+	file       string
+	refresh    time.Duration
+	minRefresh time.Duration
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	keys    []Key
+	modTime time.Time
+	size    int64
+	checked time.Time
+	forced  time.Time
+}
+
+// newKeySet creates a new keySet and loads the JWKS file, if set.
+func newKeySet(static []Key, file string, refresh, minRefresh time.Duration) (*keySet, error) {
+	s := &keySet{static: static, file: file, refresh: refresh, minRefresh: minRefresh, now: time.Now}
+	if file == "" {
+		return s, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(info); err != nil {
+		return nil, err
+	}
+	s.checked = s.now()
+	return s, nil
+}
+
+// load reads and parses the JWKS file. The caller must hold the lock.
+func (s *keySet) load(info os.FileInfo) error {
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+// reload reloads the JWKS file, if it was modified. The file is checked
+// at most once within the refresh interval or, if forced, at most once
+// within the minimum refresh interval. The current keys are kept, if
+// the modified file can not be loaded.
+func (s *keySet) reload(force bool) {
+	if s.file == "" {
+		return
+	}
+
+	now := s.now()
+
+	s.mu.RLock()
+	due := s.due(now, force)
+	s.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The file may have been checked while waiting for the lock.
+	if !s.due(now, force) {
+		return
+	}
+	if force {
+		s.forced = now
+	}
+	s.checked = now
+
+	info, err := os.Stat(s.file)
+	if err != nil || (info.ModTime().Equal(s.modTime) && info.Size() == s.size) {
+		return
+	}
+	_ = s.load(info)
+}
+
+// due reports whether the file should be checked. The caller must hold the lock.
+func (s *keySet) due(now time.Time, force bool) bool {
+	if force {
+		return now.Sub(s.forced) >= s.minRefresh
+	}
+	return now.Sub(s.checked) >= s.refresh
+}
+
+// lookup returns the keys matching the key ID. Keys without an
+// ID are returned, if no key matches the key ID exactly.
+func (s *keySet) lookup(kid string) []Key {
+	s.reload(false)
+
+	keys, exact := s.find(kid)
+	if !exact && kid != "" && s.file != "" {
+		// The key may have been added by a rotation of the keys.
+		s.reload(true)
+		keys, _ = s.find(kid)
+	}
+	return keys
+}
+
+// find returns the keys matching the key ID
+// and reports whether an exact match was found.
+func (s *keySet) find(kid string) ([]Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var exact, fallback []Key
+	for _, set := range [][]Key{s.static, s.keys} {
+		for _, k := range set {
+			switch {
+			case kid != "" && k.ID == kid:
+				exact = append(exact, k)
+			case k.ID == "" || kid == "":
+				fallback = append(fallback, k)
+			}
+		}
+	}
+	if len(exact) > 0 {
+		return exact, true
+	}
+	return fallback, false
+}
@@ -0,0 +1,169 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// writeJWKS writes the keys as JSON Web Key Set to the file.
+func writeJWKS(t *testing.T, file string, modTime time.Time, keys ...map[string]string) {
+	b, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "oct", "kid": "hs", "alg": "HS256", "k": encode([]byte("secret"))},
+		{"kty": "RSA", "kid": "rs", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "es", "crv": "P-256", "x": encode(ecKey.X.FillBytes(make([]byte, 32))), "y": encode(ecKey.Y.FillBytes(make([]byte, 32)))},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": encode(edPub)},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": encode(rsaKey.N.Bytes()), "e": "AQAB"},
+		{"kty": "EC", "kid": "p384", "crv": "P-384"},
+		{"kty": "unknown", "kid": "unknown"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, 4, len(keys))
+	assertEqual(t, Key{ID: "hs", Algorithm: HS256, Key: []byte("secret")}, keys[0])
+	assertEqual(t, true, rsaKey.PublicKey.Equal(keys[1].Key))
+	assertEqual(t, true, ecKey.PublicKey.Equal(keys[2].Key))
+	assertEqual(t, Key{ID: "ed", Key: edPub}, keys[3])
+
+	for _, k := range []map[string]string{
+		{"kty": "oct", "kid": "hs"},
+		{"kty": "RSA", "kid": "rs", "n": "!", "e": "AQAB"},
+		{"kty": "EC", "kid": "es", "crv": "P-256", "x": encode(make([]byte, 32)), "y": encode(make([]byte, 32))},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": encode([]byte("short"))},
+	} {
+		data, err := json.Marshal(map[string]any{"keys": []map[string]string{k}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseJWKS(data)
+		assertEqual(t, true, err != nil)
+	}
+}
+
+func TestJWKSRotation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	modTime := time.Now().Add(-time.Hour)
+	oldKey := map[string]string{"kty": "oct", "kid": "old", "k": encode([]byte("old"))}
+	newKey := map[string]string{"kty": "oct", "kid": "new", "k": encode([]byte("new"))}
+	writeJWKS(t, file, modTime, oldKey)
+
+	v, err := NewVerifier(func(c *Config) {
+		c.JWKSFile = file
+		c.JWKSRefresh = time.Minute
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	v.keys.now = func() time.Time { return now }
+
+	claims := map[string]any{"sub": "foo"}
+	_, err = v.Verify(sign(t, HS256, "old", []byte("old"), claims))
+	assertEqual(t, nil, err)
+
+	// A new key is loaded as soon as it is referenced by a token.
+	writeJWKS(t, file, modTime.Add(time.Minute), oldKey, newKey)
+	_, err = v.Verify(sign(t, HS256, "new", []byte("new"), claims))
+	assertEqual(t, nil, err)
+
+	// A removed key is valid until the file is checked again.
+	writeJWKS(t, file, modTime.Add(2*time.Minute), newKey)
+	_, err = v.Verify(sign(t, HS256, "old", []byte("old"), claims))
+	assertEqual(t, nil, err)
+
+	now = now.Add(time.Minute)
+	_, err = v.Verify(sign(t, HS256, "old", []byte("old"), claims))
+	assertEqual(t, ErrTokenUnverifiable, err)
+
+	// An invalid file does not replace the current keys.
+	if err := os.WriteFile(file, []byte("invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Minute)
+	_, err = v.Verify(sign(t, HS256, "new", []byte("new"), claims))
+	assertEqual(t, nil, err)
+}
+
+func TestJWKSUnknownKeyID(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	modTime := time.Now().Add(-time.Hour)
+	oldKey := map[string]string{"kty": "oct", "kid": "old", "k": encode([]byte("old"))}
+	newKey := map[string]string{"kty": "oct", "kid": "new", "k": encode([]byte("new"))}
+	writeJWKS(t, file, modTime, oldKey)
+
+	v, err := NewVerifier(func(c *Config) {
+		c.JWKSFile = file
+		c.JWKSRefresh = time.Minute
+		c.JWKSMinRefresh = 10 * time.Second
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	v.keys.now = func() time.Time { return now }
+
+	claims := map[string]any{"sub": "foo"}
+	_, err = v.Verify(sign(t, HS256, "random", []byte("random"), claims))
+	assertEqual(t, ErrTokenUnverifiable, err)
+
+	// Further unknown key IDs do not check the file
+	// again within the minimum refresh interval.
+	writeJWKS(t, file, modTime.Add(time.Minute), oldKey, newKey)
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Second)
+		_, err = v.Verify(sign(t, HS256, "new", []byte("new"), claims))
+		assertEqual(t, ErrTokenUnverifiable, err)
+	}
+
+	now = now.Add(10 * time.Second)
+	_, err = v.Verify(sign(t, HS256, "new", []byte("new"), claims))
+	assertEqual(t, nil, err)
+}
@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+	"github.com/felix-kaestner/lungo/middleware/auth"
+)
+
+// New creates a new JWT middleware instance
+//
+// The claims of a valid token are stored as the principal of the request,
+// see GetClaims. It panics if the JWKS file of the configuration can not
+// be loaded.
+func New(configure ...func(*Config)) lungo.Middleware {
+	verifier, err := NewVerifier(configure...)
+	if err != nil {
+		panic(err)
+	}
+
+	challenge := fmt.Sprintf(`Bearer realm=%q`, verifier.config.Realm)
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			token, ok := auth.BearerToken(c)
+			if !ok {
+				c.SetHeader(lungo.HeaderWWWAuthenticate, challenge)
+				return c.Error(http.StatusUnauthorized)
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				c.SetHeader(lungo.HeaderWWWAuthenticate, challenge+`, error="invalid_token"`)
+				return c.Error(http.StatusUnauthorized)
+			}
+
+			c.Set(lungo.PrincipalKey, claims)
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// GetClaims returns the claims of the token of the current
+// request or nil if the request was not authenticated.
+func GetClaims(c *lungo.Context) *Claims {
+	claims, _ := c.Principal().(*Claims)
+	return claims
+}
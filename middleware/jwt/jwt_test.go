@@ -0,0 +1,269 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+// sign creates a token signed with the key using the algorithm.
+func sign(t *testing.T, alg, kid string, key any, claims any) string {
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	digest := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(input))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		if err == nil {
+			sig = make([]byte, 64)
+			r.FillBytes(sig[:32])
+			s.FillBytes(sig[32:])
+		}
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(input))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifier(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	keys := func(c *Config) {
+		c.Keys = []Key{
+			{ID: "hs", Algorithm: HS256, Key: secret},
+			{ID: "rs", Key: &rsaKey.PublicKey},
+			{ID: "es", Key: &ecKey.PublicKey},
+			{ID: "ed", Key: edPub},
+		}
+		c.Issuer = "issuer"
+		c.Audience = "audience"
+	}
+	valid := map[string]any{"iss": "issuer", "aud": "audience", "sub": "foo", "exp": now.Unix() + 60}
+
+	var tests = []struct {
+		configure func(*Config)
+		token     string
+		err       error
+	}{
+		{token: sign(t, HS256, "hs", secret, valid)},
+		{token: sign(t, RS256, "rs", rsaKey, valid)},
+		{token: sign(t, ES256, "es", ecKey, valid)},
+		{token: sign(t, EdDSA, "ed", edKey, valid)},
+		{token: sign(t, HS256, "hs", []byte("invalid"), valid), err: ErrTokenSignatureInvalid},
+		{token: sign(t, HS256, "rs", secret, valid), err: ErrTokenSignatureInvalid},
+		{token: sign(t, RS256, "unknown", rsaKey, valid), err: ErrTokenUnverifiable},
+		{token: sign(t, "none", "hs", secret, valid), err: ErrTokenUnverifiable},
+		{
+			configure: func(c *Config) {
+				c.Algorithms = []string{RS256}
+			},
+			token: sign(t, HS256, "hs", secret, valid),
+			err:   ErrTokenUnverifiable,
+		},
+		{token: "invalid", err: ErrTokenMalformed},
+		{token: "a.b.c", err: ErrTokenMalformed},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": []string{"foo", "audience"}, "iss": "issuer"}),
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "issuer", "exp": now.Unix() - 30}),
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "issuer", "exp": now.Unix() - 60}),
+			err:   ErrTokenExpired,
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "issuer", "nbf": now.Unix() + 30}),
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "issuer", "nbf": now.Unix() + 120}),
+			err:   ErrTokenNotValidYet,
+		},
+		{
+			configure: func(c *Config) {
+				c.ClockSkew = 0
+			},
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "issuer", "exp": now.Unix()}),
+			err:   ErrTokenExpired,
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "audience", "iss": "other"}),
+			err:   ErrTokenInvalidIssuer,
+		},
+		{
+			token: sign(t, HS256, "hs", secret, map[string]any{"aud": "other", "iss": "issuer"}),
+			err:   ErrTokenInvalidAudience,
+		},
+	}
+
+	for _, testcase := range tests {
+		configure := []func(*Config){keys}
+		if testcase.configure != nil {
+			configure = append(configure, testcase.configure)
+		}
+
+		v, err := NewVerifier(configure...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.now = func() time.Time { return now }
+
+		_, err = v.Verify(testcase.token)
+		assertEqual(t, testcase.err, err)
+	}
+}
+
+func TestClaims(t *testing.T) {
+	secret := []byte("secret")
+	v, err := NewVerifier(func(c *Config) {
+		c.Keys = []Key{{Key: secret}}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := v.Verify(sign(t, HS256, "", secret, map[string]any{
+		"sub":   "foo",
+		"aud":   "bar",
+		"iat":   1700000000,
+		"scope": "read write",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, "foo", claims.Subject)
+	assertEqual(t, Audience{"bar"}, claims.Audience)
+	assertEqual(t, int64(1700000000), claims.IssuedAt.Unix())
+	assertEqual(t, (*NumericDate)(nil), claims.ExpiresAt)
+
+	var custom struct {
+		Scope string `json:"scope"`
+	}
+	if err := claims.Decode(&custom); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "read write", custom.Scope)
+}
+
+func TestJWT(t *testing.T) {
+	secret := []byte("secret")
+	middleware := New(func(c *Config) {
+		c.Keys = []Key{{Key: secret}}
+	})
+
+	var tests = []struct {
+		authorization string
+		code          int
+		authenticate  string
+		subject       string
+	}{
+		{
+			code:         http.StatusUnauthorized,
+			authenticate: `Bearer realm="Restricted"`,
+		},
+		{
+			authorization: "Bearer invalid",
+			code:          http.StatusUnauthorized,
+			authenticate:  `Bearer realm="Restricted", error="invalid_token"`,
+		},
+		{
+			authorization: "Bearer " + sign(t, HS256, "", secret, map[string]any{"sub": "foo"}),
+			code:          http.StatusNoContent,
+			subject:       "foo",
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testcase.authorization != "" {
+			req.Header.Set(lungo.HeaderAuthorization, testcase.authorization)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		subject := ""
+		h := middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			subject = GetClaims(c).Subject
+			return c.NoContent()
+		}))
+		if err := h.ServeHTTP(c); err != nil {
+			app.HandleError(c, err)
+		}
+
+		assertEqual(t, testcase.code, rr.Code)
+		assertEqual(t, testcase.authenticate, rr.Header().Get(lungo.HeaderWWWAuthenticate))
+		assertEqual(t, testcase.subject, subject)
+	}
+}
+
+func TestJWTInvalidJWKSFile(t *testing.T) {
+	defer func() {
+		assertEqual(t, true, recover() != nil)
+	}()
+	New(func(c *Config) {
+		c.JWKSFile = "testdata/missing.json"
+	})
+}
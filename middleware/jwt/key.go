@@ -0,0 +1,83 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+)
+
+// Signing algorithms supported by the JWT middleware.
+//
+// see: https://datatracker.ietf.org/doc/html/rfc7518#section-3.1
+const (
+	// HS256 is HMAC using SHA-256.
+	HS256 = "HS256"
+	// RS256 is RSASSA-PKCS1-v1_5 using SHA-256.
+	RS256 = "RS256"
+	// ES256 is ECDSA using P-256 and SHA-256.
+	ES256 = "ES256"
+	// EdDSA is EdDSA using Ed25519.
+	EdDSA = "EdDSA"
+)
+
+// Key represents a key to verify the signature of tokens.
+type Key struct {
+	// ID is matched against the "kid" header of tokens. Keys without
+	// an ID are used for tokens, whose key ID matches no other key.
+	ID string
+
+	// Algorithm restricts the key to tokens signed with the algorithm.
+	// The key may be used with any algorithm matching its type, if empty.
+	Algorithm string
+
+	// Key is the key material, which must be a []byte for HS256,
+	// a *rsa.PublicKey for RS256, an *ecdsa.PublicKey on the P-256
+	// curve for ES256 or an ed25519.PublicKey for EdDSA.
+	Key any
+}
+
+// verify reports whether sig is a valid signature of
+// the signing input using the key and algorithm.
+func (k Key) verify(alg string, input, sig []byte) bool {
+	if k.Algorithm != "" && k.Algorithm != alg {
+		return false
+	}
+
+	switch alg {
+	case HS256:
+		secret, ok := k.Key.([]byte)
+		if !ok || len(secret) == 0 {
+			return false
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		return hmac.Equal(sig, mac.Sum(nil))
+	case RS256:
+		pub, ok := k.Key.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+		h := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, h[:], sig) == nil
+	case ES256:
+		pub, ok := k.Key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve == nil || pub.Curve.Params().Name != "P-256" || len(sig) != 64 {
+			return false
+		}
+		h := sha256.Sum256(input)
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(pub, h[:], r, s)
+	case EdDSA:
+		pub, ok := k.Key.(ed25519.PublicKey)
+		if !ok || len(pub) != ed25519.PublicKeySize {
+			return false
+		}
+		return ed25519.Verify(pub, input, sig)
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTokenMalformed is returned if the token can not be parsed.
+	ErrTokenMalformed = errors.New("JWT: token is malformed")
+
+	// ErrTokenUnverifiable is returned if the algorithm of the
+	// token is not allowed or no key is found to verify the token.
+	ErrTokenUnverifiable = errors.New("JWT: token is unverifiable")
+
+	// ErrTokenSignatureInvalid is returned if the signature of the token is invalid.
+	ErrTokenSignatureInvalid = errors.New("JWT: token signature is invalid")
+
+	// ErrTokenExpired is returned if the token is expired.
+	ErrTokenExpired = errors.New("JWT: token is expired")
+
+	// ErrTokenNotValidYet is returned if the token is not valid yet.
+	ErrTokenNotValidYet = errors.New("JWT: token is not valid yet")
+
+	// ErrTokenInvalidIssuer is returned if the issuer of the token is invalid.
+	ErrTokenInvalidIssuer = errors.New("JWT: token has invalid issuer")
+
+	// ErrTokenInvalidAudience is returned if the audience of the token is invalid.
+	ErrTokenInvalidAudience = errors.New("JWT: token has invalid audience")
+)
+
+// header represents the JOSE header of a token.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verifier parses tokens, verifies their signature and validates their claims.
+type Verifier struct {
+	config     *Config
+	keys       *keySet
+	algorithms map[string]struct{}
+	now        func() time.Time
+}
+
+// NewVerifier creates a new Verifier. It returns an error
+// if the JWKS file of the configuration can not be loaded.
+func NewVerifier(configure ...func(*Config)) (*Verifier, error) {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	keys, err := newKeySet(config.Keys, config.JWKSFile, config.JWKSRefresh, config.JWKSMinRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := make(map[string]struct{}, len(config.Algorithms))
+	for _, a := range config.Algorithms {
+		algorithms[a] = struct{}{}
+	}
+
+	return &Verifier{config: config, keys: keys, algorithms: algorithms, now: time.Now}, nil
+}
+
+// Verify parses the token, verifies its signature and validates its claims.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	sig, err := decodeSegment(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if _, ok := v.algorithms[h.Alg]; !ok {
+		return nil, ErrTokenUnverifiable
+	}
+
+	keys := v.keys.lookup(h.Kid)
+	if len(keys) == 0 {
+		return nil, ErrTokenUnverifiable
+	}
+
+	input := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if k.verify(h.Alg, input, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrTokenSignatureInvalid
+	}
+
+	payload, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	claims := &Claims{raw: payload}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validate validates the registered claims of the token.
+func (v *Verifier) validate(claims *Claims) error {
+	now := v.now()
+	skew := v.config.ClockSkew
+
+	if claims.ExpiresAt != nil && !now.Before(claims.ExpiresAt.Add(skew)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(skew).Before(claims.NotBefore.Time) {
+		return ErrTokenNotValidYet
+	}
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return ErrTokenInvalidIssuer
+	}
+	if v.config.Audience != "" && !claims.Audience.Contains(v.config.Audience) {
+		return ErrTokenInvalidAudience
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url encoded segment of a token.
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// decodeJSON decodes a base64url encoded JSON segment of a token into v.
+func decodeJSON(s string, v any) error {
+	b, err := decodeSegment(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -13,6 +13,8 @@ type Config struct {
 	// Available Tags:
 	// - "Request": *http.Request
 	// - "Duration": *time.Duration
+	// - "RequestID": string, assigned by the requestid middleware
+	// - "RealIP": string, the IP address of the client, see c.RealIP()
 	//
 	// Optional. Default:
 	Template string
@@ -31,8 +31,10 @@ func New(configure ...func(*Config)) lungo.Middleware {
 				}
 
 				data := lungo.Map{
-					"Request":  c.Request,
-					"Duration": time.Since(start),
+					"Request":   c.Request,
+					"Duration":  time.Since(start),
+					"RequestID": c.RequestID(),
+					"RealIP":    c.RealIP(),
 				}
 
 				var b bytes.Buffer
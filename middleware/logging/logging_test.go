@@ -103,3 +103,25 @@ func TestTmp(t *testing.T) {
 		assertEqual(t, true, match)
 	}
 }
+
+func TestLoggingTags(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+	c.Set(lungo.RequestIDKey, "123")
+
+	b := &bytes.Buffer{}
+	h := New(func(c *Config) {
+		c.Template = "{{.RequestID}} {{.RealIP}} {{.Request.Method}}"
+		c.Logger = log.New(b, "", 0)
+	})(lungo.NotFoundHandler())
+	h.ServeHTTP(c)
+
+	assertEqual(t, "123 192.0.2.1 GET\n", b.String())
+}
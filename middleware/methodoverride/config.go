@@ -0,0 +1,35 @@
+package methodoverride
+
+import (
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the method override middleware
+type Config struct {
+	// Methods defines the methods, which may override the POST method.
+	//
+	// Optional. Default value []string{http.MethodPut, http.MethodPatch, http.MethodDelete}.
+	Methods []string
+
+	// Header defines the request header containing the method.
+	// Set this value to "" to ignore the header.
+	//
+	// Optional. Default value "X-HTTP-Method-Override".
+	Header string
+
+	// FormField defines the form field containing the method, which is
+	// used if the header is not set. Set this value to "" to ignore the form.
+	//
+	// Optional. Default value "_method".
+	FormField string
+}
+
+// DefaultConfig contains the default value for the
+// method override middleware configuration
+var DefaultConfig = &Config{
+	Methods:   []string{http.MethodPut, http.MethodPatch, http.MethodDelete},
+	Header:    lungo.HeaderXHTTPMethodOverride,
+	FormField: "_method",
+}
@@ -0,0 +1,60 @@
+package methodoverride
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// New creates a new method override middleware instance
+//
+// The middleware overrides the method of POST requests, which allows HTML
+// forms to submit requests to PUT, PATCH or DELETE routes. Since the method
+// must be overridden before the request is routed, the middleware has to be
+// added using `app.Pre()` instead of `app.Use()`.
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	methods := make(map[string]struct{}, len(config.Methods))
+	for _, m := range config.Methods {
+		methods[strings.ToUpper(m)] = struct{}{}
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			if c.Request.Method != http.MethodPost {
+				return next.ServeHTTP(c)
+			}
+
+			method := ""
+			if config.Header != "" {
+				method = c.Header(config.Header)
+			}
+			if method == "" && config.FormField != "" && isForm(c) {
+				method = c.Request.PostFormValue(config.FormField)
+			}
+
+			method = strings.ToUpper(strings.TrimSpace(method))
+			if _, ok := methods[method]; ok {
+				c.Request.Method = method
+			}
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// isForm reports whether the body of the request contains a form.
+func isForm(c *lungo.Context) bool {
+	mediatype, _, err := c.ParseMediaType()
+	if err != nil {
+		return false
+	}
+	return mediatype == lungo.MIMEApplicationForm || mediatype == lungo.MIMEMultipartForm
+}
@@ -0,0 +1,103 @@
+package methodoverride
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func TestMethodOverride(t *testing.T) {
+	var tests = []struct {
+		middleware lungo.Middleware
+		method     string
+		header     string
+		form       url.Values
+		expected   string
+	}{
+		{middleware: New(), method: "POST", expected: "POST"},
+		{middleware: New(), method: "POST", header: "PUT", expected: "PUT"},
+		{middleware: New(), method: "POST", header: "delete", expected: "DELETE"},
+		{middleware: New(), method: "POST", header: "GET", expected: "POST"},
+		{middleware: New(), method: "GET", header: "DELETE", expected: "GET"},
+		{middleware: New(), method: "POST", form: url.Values{"_method": {"PATCH"}}, expected: "PATCH"},
+		{middleware: New(), method: "POST", header: "PUT", form: url.Values{"_method": {"DELETE"}}, expected: "PUT"},
+		{middleware: New(), method: "POST", form: url.Values{"_method": {"CONNECT"}}, expected: "POST"},
+		{
+			middleware: New(func(c *Config) {
+				c.Methods = []string{http.MethodDelete}
+			}),
+			method:   "POST",
+			header:   "PUT",
+			expected: "POST",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Header = ""
+			}),
+			method:   "POST",
+			header:   "PUT",
+			expected: "POST",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.FormField = "method"
+			}),
+			method:   "POST",
+			form:     url.Values{"method": {"DELETE"}},
+			expected: "DELETE",
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(testcase.method, "/", nil)
+		if testcase.form != nil {
+			req = httptest.NewRequest(testcase.method, "/", strings.NewReader(testcase.form.Encode()))
+			req.Header.Set(lungo.HeaderContentType, lungo.MIMEApplicationForm)
+		}
+		if testcase.header != "" {
+			req.Header.Set(lungo.HeaderXHTTPMethodOverride, testcase.header)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		method := ""
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			method = c.Method()
+			return nil
+		}))
+		h.ServeHTTP(c)
+
+		assertEqual(t, testcase.expected, method)
+	}
+}
+
+func TestMethodOverrideRouting(t *testing.T) {
+	app := lungo.New()
+	app.Pre(New())
+	app.Delete("/", func(c *lungo.Context) error {
+		return c.Text(http.StatusOK, "deleted")
+	})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", strings.NewReader("_method=DELETE"))
+	req.Header.Set(lungo.HeaderContentType, lungo.MIMEApplicationForm)
+	app.ServeHTTP(rr, req)
+
+	assertEqual(t, http.StatusOK, rr.Code)
+	assertEqual(t, "deleted", rr.Body.String())
+}
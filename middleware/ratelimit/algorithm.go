@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithm defines identifiers of rate limiting algorithms.
+type Algorithm int
+
+const (
+	// TokenBucket is a bucket of Limit tokens, which is refilled
+	// continuously at a rate of Limit tokens per Period.
+	// Every request takes a token from the bucket.
+	TokenBucket Algorithm = iota
+	// SlidingWindow counts the requests in fixed windows of the length
+	// of Period and weights the count of the previous window by its
+	// overlap with the sliding window ending at the current time.
+	SlidingWindow
+)
+
+// Rule defines a limit of requests per period.
+type Rule struct {
+	Algorithm Algorithm     `json:"algorithm"`
+	Limit     int           `json:"limit"`
+	Period    time.Duration `json:"period"`
+}
+
+// State is the state of a limit for a single key.
+// The zero value is the initial state.
+type State struct {
+	// Tokens is the number of tokens in the bucket (TokenBucket).
+	Tokens float64 `json:"tokens"`
+	// Count is the number of requests in the current window (SlidingWindow).
+	Count int `json:"count"`
+	// Previous is the number of requests in the previous window (SlidingWindow).
+	Previous int `json:"previous"`
+	// Start is the time of the last refill (TokenBucket)
+	// or the start of the current window (SlidingWindow).
+	Start time.Time `json:"start"`
+}
+
+// Result is the result of taking a request from a limit.
+type Result struct {
+	// Allowed reports whether the request is allowed.
+	Allowed bool `json:"allowed"`
+	// Limit is the maximum number of requests.
+	Limit int `json:"limit"`
+	// Remaining is the number of remaining requests.
+	Remaining int `json:"remaining"`
+	// Reset is the duration until the limit is fully reset.
+	Reset time.Duration `json:"reset"`
+	// RetryAfter is the duration until the next request is allowed.
+	RetryAfter time.Duration `json:"retry_after"`
+}
+
+// Take takes a request from the limit at the given time and updates the state.
+//
+// Stores use Take to implement the Store interface, which allows
+// external stores to share the implementation of the algorithms.
+func (r Rule) Take(s *State, now time.Time) Result {
+	if r.Algorithm == SlidingWindow {
+		return r.slidingWindow(s, now)
+	}
+	return r.tokenBucket(s, now)
+}
+
+// tokenBucket implements the TokenBucket algorithm.
+func (r Rule) tokenBucket(s *State, now time.Time) Result {
+	limit := float64(r.Limit)
+	rate := limit / float64(r.Period)
+
+	if s.Start.IsZero() {
+		s.Tokens = limit
+	} else {
+		s.Tokens = math.Min(limit, s.Tokens+float64(now.Sub(s.Start))*rate)
+	}
+	s.Start = now
+
+	result := Result{Limit: r.Limit}
+	if s.Tokens >= 1 {
+		s.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - s.Tokens) / rate))
+	}
+
+	result.Remaining = int(s.Tokens)
+	result.Reset = time.Duration(math.Ceil((limit - s.Tokens) / rate))
+	return result
+}
+
+// slidingWindow implements the SlidingWindow algorithm.
+func (r Rule) slidingWindow(s *State, now time.Time) Result {
+	start := now.Truncate(r.Period)
+	switch {
+	case s.Start.Equal(start):
+	case s.Start.Add(r.Period).Equal(start):
+		s.Previous, s.Count = s.Count, 0
+	default:
+		s.Previous, s.Count = 0, 0
+	}
+	s.Start = start
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(r.Period)
+	estimate := float64(s.Previous)*weight + float64(s.Count)
+
+	result := Result{Limit: r.Limit, Reset: r.Period - elapsed}
+	if estimate+1 <= float64(r.Limit) {
+		s.Count++
+		estimate++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = r.retryAfter(s, elapsed)
+	}
+
+	result.Remaining = int(math.Max(0, math.Floor(float64(r.Limit)-estimate)))
+	if s.Previous > 0 {
+		result.Reset += r.Period
+	}
+	return result
+}
+
+// retryAfter returns the duration until the weighted count of the
+// previous window has decreased enough to allow another request.
+func (r Rule) retryAfter(s *State, elapsed time.Duration) time.Duration {
+	// Requests of the current window are only released in the next window.
+	free := float64(r.Limit - s.Count - 1)
+	if s.Previous == 0 || free < 0 {
+		return r.Period - elapsed
+	}
+
+	// Solve previous * (1 - t/period) <= free for t.
+	t := time.Duration(math.Ceil((1 - free/float64(s.Previous)) * float64(r.Period)))
+	if t <= elapsed {
+		return 0
+	}
+	return t - elapsed
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	rule := Rule{Algorithm: TokenBucket, Limit: 2, Period: 2 * time.Second}
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	state := &State{}
+
+	var tests = []struct {
+		elapsed time.Duration
+		result  Result
+	}{
+		{elapsed: 0, result: Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}},
+		{elapsed: 0, result: Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second}},
+		{elapsed: 0, result: Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second}},
+		{elapsed: 500 * time.Millisecond, result: Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+		{elapsed: 500 * time.Millisecond, result: Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second}},
+		{elapsed: 10 * time.Second, result: Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}},
+	}
+
+	for _, testcase := range tests {
+		now = now.Add(testcase.elapsed)
+		assertEqual(t, testcase.result, rule.Take(state, now))
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	rule := Rule{Algorithm: SlidingWindow, Limit: 2, Period: 10 * time.Second}
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	state := &State{}
+
+	var tests = []struct {
+		elapsed time.Duration
+		result  Result
+	}{
+		{elapsed: 0, result: Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 10 * time.Second}},
+		{elapsed: 5 * time.Second, result: Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 5 * time.Second}},
+		{elapsed: 0, result: Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 5 * time.Second, RetryAfter: 5 * time.Second}},
+		// The previous window counts 2 requests with a weight of 0.5
+		{elapsed: 10 * time.Second, result: Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 15 * time.Second}},
+		{elapsed: 0, result: Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 15 * time.Second, RetryAfter: 5 * time.Second}},
+		{elapsed: 30 * time.Second, result: Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 5 * time.Second}},
+	}
+
+	for _, testcase := range tests {
+		now = now.Add(testcase.elapsed)
+		assertEqual(t, testcase.result, rule.Take(state, now))
+	}
+}
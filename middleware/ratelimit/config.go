@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the rate limit middleware
+type Config struct {
+	// Algorithm defines the algorithm used to limit the requests.
+	//
+	// Possible values:
+	// - TokenBucket - Allows bursts of up to Limit requests and refills continuously.
+	// - SlidingWindow - Allows Limit requests within any window of the length of Period.
+	//
+	// Optional. Default value TokenBucket.
+	Algorithm Algorithm
+
+	// Limit defines the maximum number of requests per Period.
+	// It must be positive.
+	//
+	// Optional. Default value 100.
+	Limit int
+
+	// Period defines the duration of the period, in which at most
+	// Limit requests are allowed. It must be positive.
+	//
+	// Optional. Default value 1 * time.Minute.
+	Period time.Duration
+
+	// KeyGenerator defines a function to generate the key, by which
+	// requests are limited, e.g. KeyByIP() or KeyByHeader("X-API-Key").
+	// Requests with an empty key, e.g. without the header, share a single limit.
+	//
+	// Optional. Default value KeyByIP().
+	KeyGenerator func(c *lungo.Context) string
+
+	// Store defines the storage of the state of the limits.
+	// Use a Store backed by an external database to share
+	// the limits between multiple instances of an application.
+	//
+	// Optional. Default value NewMemoryStore().
+	Store Store
+
+	// Headers defines whether or not the `RateLimit-Limit`, `RateLimit-Remaining`
+	// and `RateLimit-Reset` headers are set on the response.
+	//
+	// see: https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/
+	//
+	// Optional. Default value true.
+	Headers bool
+}
+
+// DefaultConfig contains the default value for the
+// rate limit middleware configuration
+var DefaultConfig = &Config{
+	Algorithm:    TokenBucket,
+	Limit:        100,
+	Period:       1 * time.Minute,
+	KeyGenerator: nil,
+	Store:        nil,
+	Headers:      true,
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// New creates a new rate limit middleware instance
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	generateKey := config.KeyGenerator
+	if generateKey == nil {
+		generateKey = KeyByIP()
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
+
+	if config.Limit <= 0 {
+		panic(fmt.Sprintf("Invalid limit. The limit must be positive, but is %d.", config.Limit))
+	}
+
+	if config.Period <= 0 {
+		panic(fmt.Sprintf("Invalid period. The period must be positive, but is %s.", config.Period))
+	}
+
+	rule := Rule{Algorithm: config.Algorithm, Limit: config.Limit, Period: config.Period}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			// Requests with an empty key share a single limit, such that
+			// clients can not evade the limit by omitting the key.
+			result, err := store.Take(generateKey(c), rule)
+			if err != nil {
+				return err
+			}
+
+			if config.Headers {
+				c.SetHeader(lungo.HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+				c.SetHeader(lungo.HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+				c.SetHeader(lungo.HeaderRateLimitReset, seconds(result.Reset))
+			}
+
+			if !result.Allowed {
+				c.SetHeader(lungo.HeaderRetryAfter, seconds(result.RetryAfter))
+				return c.Error(http.StatusTooManyRequests)
+			}
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// KeyByIP returns a key generator, which limits requests by the IP address
+// of the client. Requests received from trusted proxies are limited by the
+// address of the client, see `c.RealIP()`.
+func KeyByIP() func(c *lungo.Context) string {
+	return func(c *lungo.Context) string {
+		return c.RealIP()
+	}
+}
+
+// KeyByHeader returns a key generator, which limits requests by the value
+// of the request header with the given name, e.g. an API key.
+func KeyByHeader(name string) func(c *lungo.Context) string {
+	return func(c *lungo.Context) string {
+		return c.Header(name)
+	}
+}
+
+// seconds formats the duration as number of seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
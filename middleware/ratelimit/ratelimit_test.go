@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+type failingStore struct{}
+
+// Implement `Take` method of Store interface
+func (s *failingStore) Take(key string, rule Rule) (Result, error) {
+	return Result{}, errors.New("Take: failed")
+}
+
+func TestRateLimit(t *testing.T) {
+	type request struct {
+		remoteAddr string
+		header     map[string]string
+		status     int
+		remaining  string
+		retryAfter string
+	}
+
+	var tests = []struct {
+		middleware lungo.Middleware
+		requests   []request
+	}{
+		{
+			middleware: New(func(c *Config) {
+				c.Limit = 2
+			}),
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", status: http.StatusOK, remaining: "1"},
+				{remoteAddr: "192.0.2.1:5678", status: http.StatusOK, remaining: "0"},
+				{remoteAddr: "192.0.2.1:1234", status: http.StatusTooManyRequests, remaining: "0", retryAfter: "30"},
+				{remoteAddr: "192.0.2.2:1234", status: http.StatusOK, remaining: "1"},
+			},
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Algorithm = SlidingWindow
+				c.Limit = 1
+				c.Period = time.Hour
+				c.KeyGenerator = KeyByHeader("X-API-Key")
+			}),
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", header: map[string]string{"X-API-Key": "foo"}, status: http.StatusOK, remaining: "0"},
+				{remoteAddr: "192.0.2.2:1234", header: map[string]string{"X-API-Key": "foo"}, status: http.StatusTooManyRequests, remaining: "0"},
+				{remoteAddr: "192.0.2.2:1234", header: map[string]string{"X-API-Key": "bar"}, status: http.StatusOK, remaining: "0"},
+				{remoteAddr: "192.0.2.2:1234", status: http.StatusOK, remaining: "0"},
+				{remoteAddr: "192.0.2.3:1234", status: http.StatusTooManyRequests, remaining: "0"},
+			},
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Limit = 1
+				c.Headers = false
+			}),
+			requests: []request{
+				{remoteAddr: "192.0.2.1", status: http.StatusOK},
+				{remoteAddr: "192.0.2.1", status: http.StatusTooManyRequests, retryAfter: "60"},
+			},
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Limit = 1
+			}),
+			requests: []request{
+				{remoteAddr: "", status: http.StatusOK, remaining: "0"},
+				{remoteAddr: "", status: http.StatusTooManyRequests, remaining: "0", retryAfter: "60"},
+			},
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Store = &failingStore{}
+			}),
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1234", status: http.StatusInternalServerError},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			return c.Text(http.StatusOK, "OK")
+		}))
+
+		for _, r := range testcase.requests {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.RemoteAddr = r.remoteAddr
+			for k, v := range r.header {
+				req.Header.Set(k, v)
+			}
+
+			app := lungo.New()
+			c := app.NewContext(rr, req)
+
+			err = h.ServeHTTP(c)
+			if re, ok := err.(*lungo.RequestError); ok {
+				rr.Code = re.Code
+			} else if err != nil {
+				rr.Code = http.StatusInternalServerError
+			}
+
+			assertEqual(t, r.status, rr.Code)
+			assertEqual(t, r.remaining, rr.Header().Get(lungo.HeaderRateLimitRemaining))
+			if r.retryAfter != "" {
+				assertEqual(t, r.retryAfter, rr.Header().Get(lungo.HeaderRetryAfter))
+			}
+		}
+	}
+}
+
+func TestRateLimitInvalidConfig(t *testing.T) {
+	var tests = []struct {
+		configure func(*Config)
+		expected  string
+	}{
+		{configure: func(c *Config) { c.Limit = 0 }, expected: "Invalid limit. The limit must be positive, but is 0."},
+		{configure: func(c *Config) { c.Limit = -1 }, expected: "Invalid limit. The limit must be positive, but is -1."},
+		{configure: func(c *Config) { c.Period = 0 }, expected: "Invalid period. The period must be positive, but is 0s."},
+	}
+
+	for _, testcase := range tests {
+		func() {
+			defer func() {
+				assertEqual(t, testcase.expected, recover())
+			}()
+			New(testcase.configure)
+		}()
+	}
+}
+
+func TestKeyByIP(t *testing.T) {
+	app := lungo.New(func(c *lungo.Config) {
+		c.TrustedProxies = []string{"10.0.0.0/8"}
+	})
+
+	var tests = []struct {
+		remoteAddr string
+		forwarded  string
+		key        string
+	}{
+		{remoteAddr: "192.0.2.1:1234", key: "192.0.2.1"},
+		{remoteAddr: "192.0.2.1:1234", forwarded: "203.0.113.1", key: "192.0.2.1"},
+		{remoteAddr: "10.0.0.1:1234", forwarded: "203.0.113.1", key: "203.0.113.1"},
+	}
+
+	for _, testcase := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = testcase.remoteAddr
+		if testcase.forwarded != "" {
+			req.Header.Set(lungo.HeaderXForwardedFor, testcase.forwarded)
+		}
+
+		c := app.NewContext(httptest.NewRecorder(), req)
+		assertEqual(t, testcase.key, KeyByIP()(c))
+	}
+}
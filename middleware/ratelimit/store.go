@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// Store is the interface of a storage for the state of limits.
+// Implementations must be safe for concurrent use.
+//
+// Take takes a request from the limit of the key according to the rule
+// and must update the state of the key atomically. Implementations
+// usually load the State of the key, call `rule.Take()` and save it.
+type Store interface {
+	Take(key string, rule Rule) (Result, error)
+}
+
+// shards defines the number of shards of the MemoryStore.
+const shards = 64
+
+// sweepInterval defines the minimum interval between
+// sweeps of expired entries of a shard.
+const sweepInterval = 1 * time.Minute
+
+// entry is an element of the MemoryStore.
+type entry struct {
+	state   State
+	expires time.Time
+}
+
+// shard is a partition of the MemoryStore.
+type shard struct {
+	mutex   sync.Mutex
+	entries map[string]*entry
+	swept   time.Time
+}
+
+// MemoryStore is an in-memory Store, which is partitioned into shards
+// to reduce lock contention. Expired entries are swept periodically.
+type MemoryStore struct {
+	shards [shards]*shard
+	now    func() time.Time
+}
+
+// NewMemoryStore creates a new MemoryStore instance.
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{now: time.Now}
+	for i := range s.shards {
+		s.shards[i] = &shard{entries: make(map[string]*entry)}
+	}
+	return s
+}
+
+// Take implements the Store interface.
+func (s *MemoryStore) Take(key string, rule Rule) (Result, error) {
+	now := s.now()
+	sh := s.shard(key)
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	if now.Sub(sh.swept) > sweepInterval {
+		for k, e := range sh.entries {
+			if now.After(e.expires) {
+				delete(sh.entries, k)
+			}
+		}
+		sh.swept = now
+	}
+
+	e, ok := sh.entries[key]
+	if !ok || now.After(e.expires) {
+		e = &entry{}
+		sh.entries[key] = e
+	}
+
+	result := rule.Take(&e.state, now)
+
+	// The state of a key is equivalent to the initial
+	// state after two periods without any requests.
+	e.expires = now.Add(2 * rule.Period)
+
+	return result, nil
+}
+
+// Len returns the number of keys in the store.
+func (s *MemoryStore) Len() int {
+	n := 0
+	for _, sh := range s.shards {
+		sh.mutex.Lock()
+		n += len(sh.entries)
+		sh.mutex.Unlock()
+	}
+	return n
+}
+
+// shard returns the shard of the key.
+func (s *MemoryStore) shard(key string) *shard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.shards[h.Sum32()%shards]
+}
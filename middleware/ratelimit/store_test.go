@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	rule := Rule{Algorithm: TokenBucket, Limit: 1, Period: time.Second}
+
+	result, err := s.Take("a", rule)
+	assertEqual(t, nil, err)
+	assertEqual(t, true, result.Allowed)
+
+	result, _ = s.Take("a", rule)
+	assertEqual(t, false, result.Allowed)
+
+	result, _ = s.Take("b", rule)
+	assertEqual(t, true, result.Allowed)
+	assertEqual(t, 2, s.Len())
+
+	// Expired entries are swept
+	now = now.Add(2 * sweepInterval)
+	result, _ = s.Take("a", rule)
+	assertEqual(t, true, result.Allowed)
+
+	// Find another key in the shard of "c"
+	s.Take("c", rule)
+	key := ""
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprint(i); s.shard(k) == s.shard("c") {
+			key = k
+		}
+	}
+
+	now = now.Add(2 * sweepInterval)
+	s.Take(key, rule)
+
+	_, ok := s.shard("c").entries["c"]
+	assertEqual(t, false, ok)
+}
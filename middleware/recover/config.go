@@ -5,6 +5,9 @@ type Config struct {
 	// Handle defines a callback function to handle
 	// the stack trace of the panic.
 	//
+	// The error returned by the middleware is annotated with
+	// the ID of the request, if assigned by the requestid middleware.
+	//
 	// Optional. Default: nil
 	HandleStackTrace func(e any)
 }
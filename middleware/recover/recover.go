@@ -28,6 +28,11 @@ func New(configure ...func(*Config)) lungo.Middleware {
 						// Set error that will call the global error handler
 						err = fmt.Errorf("%v", r)
 					}
+
+					// Annotate the error with the ID of the request, if assigned.
+					if id := c.RequestID(); id != "" {
+						err = fmt.Errorf("request %s: %w", id, err)
+					}
 				}
 			}()
 
@@ -53,3 +53,20 @@ func TestRecover(t *testing.T) {
 		h.ServeHTTP(c)
 	}
 }
+
+func TestRecoverRequestID(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+	c.Set(lungo.RequestIDKey, "123")
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) (err error) {
+		panic("Error")
+	}))
+	assertEqual(t, "request 123: Error", h.ServeHTTP(c).Error())
+}
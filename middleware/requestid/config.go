@@ -0,0 +1,33 @@
+package requestid
+
+import (
+	"github.com/felix-kaestner/lungo"
+)
+
+// Config defines the configuration options for the request ID middleware
+type Config struct {
+	// Header defines the request and response header containing the request ID.
+	//
+	// Optional. Default value "X-Request-ID".
+	Header string
+
+	// Generator defines a function to generate a new request ID.
+	//
+	// Optional. Default value is a function generating a random UUID (version 4).
+	Generator func() (string, error)
+
+	// Validator defines a function to validate the request ID of an incoming
+	// request. A new ID is generated, if the incoming ID is invalid.
+	// By default IDs of up to 128 alphanumeric characters, "-", "_", "." and ":" are valid.
+	//
+	// Optional. Default value is a function validating the ID as described.
+	Validator func(id string) bool
+}
+
+// DefaultConfig contains the default value for the
+// request ID middleware configuration
+var DefaultConfig = &Config{
+	Header:    lungo.HeaderXRequestID,
+	Generator: generate,
+	Validator: validate,
+}
@@ -0,0 +1,82 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// contextKey is the type of the key of the request ID in the request context.
+type contextKey struct{}
+
+// ContextKey defines the key to retrieve the request ID
+// from the context of the request.
+var ContextKey = contextKey{}
+
+// maxLength defines the maximum length of a valid request ID.
+const maxLength = 128
+
+// New creates a new request ID middleware instance
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			id := c.Header(config.Header)
+			if id == "" || !config.Validator(id) {
+				var err error
+				if id, err = config.Generator(); err != nil {
+					return err
+				}
+			}
+
+			c.SetHeader(config.Header, id)
+			c.Set(lungo.RequestIDKey, id)
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ContextKey, id))
+
+			return next.ServeHTTP(c)
+		})
+	}
+}
+
+// FromContext returns the request ID stored in the context
+// or the empty string if the context contains no request ID.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ContextKey).(string)
+	return id
+}
+
+// generate returns a random UUID (version 4).
+func generate() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// validate reports whether the request ID consists of up to 128
+// alphanumeric characters, "-", "_", "." and ":".
+func validate(id string) bool {
+	if len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
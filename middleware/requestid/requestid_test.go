@@ -0,0 +1,137 @@
+package requestid
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+const uuidRegex = `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func TestRequestID(t *testing.T) {
+	var tests = []struct {
+		middleware lungo.Middleware
+		header     string
+		id         string
+		generated  bool
+		err        error
+	}{
+		{
+			middleware: New(),
+			generated:  true,
+		},
+		{
+			middleware: New(),
+			header:     "abc-123_XYZ.4:5",
+			id:         "abc-123_XYZ.4:5",
+		},
+		{
+			middleware: New(),
+			header:     "<script>",
+			generated:  true,
+		},
+		{
+			middleware: New(),
+			header:     strings.Repeat("a", 129),
+			generated:  true,
+		},
+		{
+			middleware: New(),
+			header:     strings.Repeat("a", 128),
+			id:         strings.Repeat("a", 128),
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Generator = func() (string, error) { return "foo", nil }
+			}),
+			id: "foo",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Generator = func() (string, error) { return "foo", nil }
+				c.Validator = func(id string) bool { return id == "bar" }
+			}),
+			header: "baz",
+			id:     "foo",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Generator = func() (string, error) { return "", errors.New("Error") }
+			}),
+			err: errors.New("Error"),
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testcase.header != "" {
+			req.Header.Set(lungo.HeaderXRequestID, testcase.header)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		var fromContext string
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			fromContext = FromContext(c.Request.Context())
+			return nil
+		}))
+		err = h.ServeHTTP(c)
+		assertEqual(t, testcase.err, err)
+		if err != nil {
+			continue
+		}
+
+		id := rr.Header().Get(lungo.HeaderXRequestID)
+		if testcase.generated {
+			match, err := regexp.MatchString(uuidRegex, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertEqual(t, true, match)
+		} else {
+			assertEqual(t, testcase.id, id)
+		}
+		assertEqual(t, id, c.RequestID())
+		assertEqual(t, id, fromContext)
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Correlation-ID", "123")
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	h := New(func(c *Config) {
+		c.Header = "X-Correlation-ID"
+	})(lungo.NotFoundHandler())
+	h.ServeHTTP(c)
+
+	assertEqual(t, "123", rr.Header().Get("X-Correlation-ID"))
+	assertEqual(t, "", rr.Header().Get(lungo.HeaderXRequestID))
+	assertEqual(t, "123", c.RequestID())
+}
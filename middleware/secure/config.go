@@ -40,6 +40,17 @@ type Config struct {
 	// Optional. Default value "".
 	ContentSecurityPolicy string
 
+	// ContentSecurityPolicyNonce enables the generation of a cryptographic nonce
+	// for every request. Each occurrence of `{nonce}` in the ContentSecurityPolicy
+	// is replaced by the generated nonce, e.g. "script-src 'nonce-{nonce}'".
+	// Handlers can retrieve the nonce by calling `c.Nonce()` in order to set the
+	// `nonce` attribute of inline script and style elements.
+	//
+	// see: https://developer.mozilla.org/en-US/docs/Web/HTML/Global_attributes/nonce
+	//
+	// Optional. Default value false.
+	ContentSecurityPolicyNonce bool
+
 	// ContentTypeNosniff provides protection against overriding Content-Type
 	// header by setting the `X-Content-Type-Options` header.
 	//
@@ -55,14 +66,42 @@ type Config struct {
 	//
 	// Optional. Default value "".
 	ReferrerPolicy string
+
+	// HSTSMaxAge instructs browsers to only access the site via HTTPS for the
+	// given number of seconds by setting the `Strict-Transport-Security` header.
+	// The header is only set on requests received via HTTPS, which takes the
+	// trusted proxies of the application into account, see `c.Scheme()`.
+	//
+	// see: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security
+	//
+	// Optional. Default value 0, which disables the header.
+	HSTSMaxAge int
+
+	// HSTSIncludeSubdomains applies the `Strict-Transport-Security` header
+	// to all subdomains of the site as well.
+	//
+	// Optional. Default value false.
+	HSTSIncludeSubdomains bool
+
+	// HSTSPreload indicates the consent of the site to be included
+	// in the HSTS preload lists of browsers.
+	//
+	// see: https://hstspreload.org
+	//
+	// Optional. Default value false.
+	HSTSPreload bool
 }
 
 // DefaultConfig contains the default value for the
 // secure middleware configuration
 var DefaultConfig = &Config{
-	XSSProtection:         "1; mode=block",
-	XFrameOptions:         "SAMEORIGIN",
-	ContentSecurityPolicy: "",
-	ContentTypeNosniff:    "nosniff",
-	ReferrerPolicy:        "",
+	XSSProtection:              "1; mode=block",
+	XFrameOptions:              "SAMEORIGIN",
+	ContentSecurityPolicy:      "",
+	ContentSecurityPolicyNonce: false,
+	ContentTypeNosniff:         "nosniff",
+	ReferrerPolicy:             "",
+	HSTSMaxAge:                 0,
+	HSTSIncludeSubdomains:      false,
+	HSTSPreload:                false,
 }
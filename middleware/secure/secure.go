@@ -1,9 +1,23 @@
 package secure
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
 	"github.com/felix-kaestner/lungo"
 )
 
+const (
+	// NoncePlaceholder is substituted by the generated nonce
+	// inside of the Content-Security-Policy header.
+	NoncePlaceholder = "{nonce}"
+
+	// nonceSize defines the number of random bytes of a nonce.
+	nonceSize = 16
+)
+
 // New creates a new secure middleware instance
 func New(configure ...func(*Config)) lungo.Middleware {
 	config := new(Config)
@@ -13,6 +27,17 @@ func New(configure ...func(*Config)) lungo.Middleware {
 		c(config)
 	}
 
+	hsts := ""
+	if config.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", config.HSTSMaxAge)
+		if config.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if config.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
 	return func(next lungo.Handler) lungo.Handler {
 		return lungo.HandlerFunc(func(c *lungo.Context) error {
 			if config.XSSProtection != "" {
@@ -21,8 +46,19 @@ func New(configure ...func(*Config)) lungo.Middleware {
 			if config.XFrameOptions != "" {
 				c.AddHeader(lungo.HeaderXFrameOptions, config.XFrameOptions)
 			}
+			if config.ContentSecurityPolicyNonce {
+				nonce, err := generateNonce()
+				if err != nil {
+					return err
+				}
+				c.Set(lungo.NonceKey, nonce)
+			}
 			if config.ContentSecurityPolicy != "" {
-				c.AddHeader(lungo.HeaderContentSecurityPolicy, config.ContentSecurityPolicy)
+				csp := config.ContentSecurityPolicy
+				if config.ContentSecurityPolicyNonce {
+					csp = strings.ReplaceAll(csp, NoncePlaceholder, c.Nonce())
+				}
+				c.AddHeader(lungo.HeaderContentSecurityPolicy, csp)
 			}
 			if config.ContentTypeNosniff != "" {
 				c.AddHeader(lungo.HeaderXContentTypeOptions, config.ContentTypeNosniff)
@@ -30,7 +66,19 @@ func New(configure ...func(*Config)) lungo.Middleware {
 			if config.ReferrerPolicy != "" {
 				c.AddHeader(lungo.HeaderReferrerPolicy, config.ReferrerPolicy)
 			}
+			if hsts != "" && c.Scheme() == "https" {
+				c.AddHeader(lungo.HeaderStrictTransportSecurity, hsts)
+			}
 			return next.ServeHTTP(c)
 		})
 	}
 }
+
+// generateNonce returns a base64 encoded cryptographic random nonce.
+func generateNonce() (string, error) {
+	b := make([]byte, nonceSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
@@ -66,3 +66,102 @@ func TestSecure(t *testing.T) {
 		testcase.eval(rr.Header())
 	}
 }
+
+func TestSecureNonce(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	var nonce string
+	h := New(func(c *Config) {
+		c.ContentSecurityPolicy = "script-src 'nonce-{nonce}'; style-src 'nonce-{nonce}'"
+		c.ContentSecurityPolicyNonce = true
+	})(lungo.HandlerFunc(func(c *lungo.Context) error {
+		nonce = c.Nonce()
+		return c.NoContent()
+	}))
+	h.ServeHTTP(c)
+
+	assertEqual(t, 24, len(nonce))
+	assertEqual(t, "script-src 'nonce-"+nonce+"'; style-src 'nonce-"+nonce+"'", rr.Header().Get(lungo.HeaderContentSecurityPolicy))
+
+	// A new nonce is generated for every request
+	rr = httptest.NewRecorder()
+	c.Reset(rr, req)
+	previous := nonce
+	h.ServeHTTP(c)
+	assertEqual(t, false, previous == nonce)
+}
+
+func TestSecureHSTS(t *testing.T) {
+	var tests = []struct {
+		configure  func(*Config)
+		remoteAddr string
+		header     http.Header
+		hsts       string
+	}{
+		{
+			configure:  func(c *Config) {},
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{lungo.HeaderXForwardedProto: {"https"}},
+		},
+		{
+			configure: func(c *Config) {
+				c.HSTSMaxAge = 31536000
+			},
+			remoteAddr: "192.0.2.1:1234",
+		},
+		{
+			configure: func(c *Config) {
+				c.HSTSMaxAge = 31536000
+			},
+			remoteAddr: "192.0.2.1:1234",
+			header:     http.Header{lungo.HeaderXForwardedProto: {"https"}},
+		},
+		{
+			configure: func(c *Config) {
+				c.HSTSMaxAge = 31536000
+			},
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{lungo.HeaderXForwardedProto: {"https"}},
+			hsts:       "max-age=31536000",
+		},
+		{
+			configure: func(c *Config) {
+				c.HSTSMaxAge = 63072000
+				c.HSTSIncludeSubdomains = true
+				c.HSTSPreload = true
+			},
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{lungo.HeaderXForwardedProto: {"https"}},
+			hsts:       "max-age=63072000; includeSubDomains; preload",
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = testcase.remoteAddr
+		for k, v := range testcase.header {
+			req.Header[k] = v
+		}
+
+		app := lungo.New(func(c *lungo.Config) {
+			c.TrustedProxies = []string{"10.0.0.0/8"}
+		})
+		c := app.NewContext(rr, req)
+
+		h := New(testcase.configure)(lungo.NotFoundHandler())
+		h.ServeHTTP(c)
+
+		assertEqual(t, testcase.hsts, rr.Header().Get(lungo.HeaderStrictTransportSecurity))
+	}
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"net/http"
+)
+
+// Config defines the configuration options for the session middleware
+type Config struct {
+	// Store defines the storage of the sessions.
+	//
+	// Optional. Default value NewMemoryStore().
+	Store Store
+
+	// CookieName defines the name of the session cookie.
+	//
+	// Optional. Default value "session".
+	CookieName string
+
+	// CookieDomain defines the domain of the session cookie.
+	//
+	// Optional. Default value "".
+	CookieDomain string
+
+	// CookiePath defines the path of the session cookie.
+	//
+	// Optional. Default value "/".
+	CookiePath string
+
+	// CookieSecure defines whether or not the session cookie is only sent via HTTPS.
+	//
+	// Optional. Default value false.
+	CookieSecure bool
+
+	// CookieHTTPOnly defines whether or not the session cookie is inaccessible to JavaScript.
+	//
+	// Optional. Default value true.
+	CookieHTTPOnly bool
+
+	// CookieSameSite defines the SameSite attribute of the session cookie.
+	//
+	// Optional. Default value http.SameSiteLaxMode.
+	CookieSameSite http.SameSite
+
+	// MaxAge defines the lifetime of a session in seconds.
+	//
+	// Optional. Default value 86400 (24 hours).
+	MaxAge int
+}
+
+// DefaultConfig contains the default value for the
+// session middleware configuration
+var DefaultConfig = &Config{
+	Store:          nil,
+	CookieName:     "session",
+	CookieDomain:   "",
+	CookiePath:     "/",
+	CookieSecure:   false,
+	CookieHTTPOnly: true,
+	CookieSameSite: http.SameSiteLaxMode,
+	MaxAge:         86400,
+}
@@ -0,0 +1,145 @@
+package session
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"time"
+)
+
+// maxCookieSize defines the maximum size of a cookie value supported by browsers.
+const maxCookieSize = 4096
+
+// ErrCookieTooLarge is returned by the CookieStore, if the encoded
+// session exceeds the maximum size of a cookie of 4096 bytes.
+var ErrCookieTooLarge = errors.New("Session: encoded session exceeds 4096 bytes")
+
+func init() {
+	// Flash messages are stored as []any.
+	gob.Register([]any{})
+}
+
+// record is the encoded representation of a session inside of a cookie.
+type record struct {
+	ID      string
+	Values  map[string]any
+	Expires int64
+}
+
+// cookieKey contains the keys derived from a secret of the CookieStore.
+type cookieKey struct {
+	aead cipher.AEAD
+	hmac []byte
+}
+
+// CookieStore is a Store, which stores the sessions inside of the session
+// cookie. The values of a session are encoded using encoding/gob, encrypted
+// using AES-256-GCM and authenticated using HMAC-SHA256, thus custom types
+// stored in a session must be registered using `gob.Register()`.
+type CookieStore struct {
+	keys []cookieKey
+	now  func() time.Time
+}
+
+// NewCookieStore creates a new CookieStore. Sessions are encoded using the
+// first secret, while all secrets are accepted when decoding a session,
+// which allows to rotate the secrets. It panics if no secret is provided.
+func NewCookieStore(secrets ...[]byte) *CookieStore {
+	if len(secrets) == 0 {
+		panic("Missing secret. The cookie store requires at least one secret.")
+	}
+
+	s := &CookieStore{now: time.Now}
+	for _, secret := range secrets {
+		block, err := aes.NewCipher(derive(secret, "encryption"))
+		if err != nil {
+			panic(err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			panic(err)
+		}
+		s.keys = append(s.keys, cookieKey{aead: aead, hmac: derive(secret, "authentication")})
+	}
+	return s
+}
+
+// Load implements the Store interface.
+func (s *CookieStore) Load(value string) (string, map[string]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(b) < sha256.Size {
+		return "", nil, ErrNotFound
+	}
+	data, sum := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+
+	for _, k := range s.keys {
+		mac := hmac.New(sha256.New, k.hmac)
+		mac.Write(data)
+		if !hmac.Equal(sum, mac.Sum(nil)) || len(data) < k.aead.NonceSize() {
+			continue
+		}
+
+		nonce, ciphertext := data[:k.aead.NonceSize()], data[k.aead.NonceSize():]
+		plaintext, err := k.aead.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			continue
+		}
+
+		var r record
+		if err := gob.NewDecoder(bytes.NewReader(plaintext)).Decode(&r); err != nil {
+			return "", nil, ErrNotFound
+		}
+		if s.now().Unix() >= r.Expires {
+			return "", nil, ErrNotFound
+		}
+		if r.Values == nil {
+			r.Values = make(map[string]any)
+		}
+		return r.ID, r.Values, nil
+	}
+	return "", nil, ErrNotFound
+}
+
+// Save implements the Store interface.
+func (s *CookieStore) Save(id string, values map[string]any, ttl time.Duration) (string, error) {
+	var buf bytes.Buffer
+	r := record{ID: id, Values: values, Expires: s.now().Add(ttl).Unix()}
+	if err := gob.NewEncoder(&buf).Encode(r); err != nil {
+		return "", err
+	}
+
+	k := s.keys[0]
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	data := k.aead.Seal(nonce, nonce, buf.Bytes(), nil)
+
+	mac := hmac.New(sha256.New, k.hmac)
+	mac.Write(data)
+	value := base64.RawURLEncoding.EncodeToString(mac.Sum(data))
+
+	if len(value) > maxCookieSize {
+		return "", ErrCookieTooLarge
+	}
+	return value, nil
+}
+
+// Delete implements the Store interface. Sessions stored inside
+// of the cookie are removed by expiring the session cookie.
+func (s *CookieStore) Delete(id string) error {
+	return nil
+}
+
+// derive derives a 256 bit key for the given purpose from the secret.
+func derive(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,258 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// ErrMiddlewareMissing is returned by Get, if the
+// session middleware is not applied to the request.
+var ErrMiddlewareMissing = errors.New("Session: no session middleware registered")
+
+// flashKey is the key of the flash messages inside of the values of a session.
+const flashKey = "_flash"
+
+// Session represents the session of a client.
+//
+// A Session is not safe for concurrent use by multiple goroutines.
+type Session struct {
+	id         string
+	values     map[string]any
+	isNew      bool
+	modified   bool
+	regenerate bool
+	destroyed  bool
+}
+
+// newSession creates a new empty session with a random ID.
+func newSession() (*Session, error) {
+	id, err := generateID()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{id: id, values: make(map[string]any), isNew: true}, nil
+}
+
+// ID returns the ID of the session.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// IsNew reports whether the session was created by the current request.
+func (s *Session) IsNew() bool {
+	return s.isNew
+}
+
+// Get returns the value stored in the session for the given key
+// or nil if there is no value associated with the key.
+func (s *Session) Get(key string) any {
+	return s.values[key]
+}
+
+// Set stores the value in the session for the given key.
+// It replaces any existing value associated with the key.
+func (s *Session) Set(key string, value any) {
+	s.values[key] = value
+	s.modified = true
+}
+
+// Delete removes the value associated with the key from the session.
+func (s *Session) Delete(key string) {
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.modified = true
+	}
+}
+
+// Clear removes all values from the session.
+func (s *Session) Clear() {
+	if len(s.values) > 0 {
+		s.values = make(map[string]any)
+		s.modified = true
+	}
+}
+
+// AddFlash adds a flash message to the session, which
+// is kept until it is read by a call to `Flashes()`.
+func (s *Session) AddFlash(value any) {
+	flashes, _ := s.values[flashKey].([]any)
+	s.values[flashKey] = append(flashes, value)
+	s.modified = true
+}
+
+// Flashes returns and removes the flash messages of the session.
+func (s *Session) Flashes() []any {
+	flashes, _ := s.values[flashKey].([]any)
+	s.Delete(flashKey)
+	return flashes
+}
+
+// Regenerate assigns a new ID to the session, while keeping its values.
+// The session should be regenerated whenever the privilege level of the
+// client changes, e.g. on login, to prevent session fixation attacks.
+func (s *Session) Regenerate() {
+	s.regenerate = true
+	s.modified = true
+}
+
+// Destroy removes the session from the store and expires the session cookie.
+func (s *Session) Destroy() {
+	s.values = make(map[string]any)
+	s.destroyed = true
+}
+
+// generateID returns a base64url encoded cryptographic random session ID.
+func generateID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// New creates a new session middleware instance
+//
+// The session is loaded lazily on the first call to Get and saved before
+// the response is written, if it was modified.
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			h := &handle{config: config, c: c}
+			c.Set(lungo.SessionKey, h)
+
+			w := c.Response
+			c.Response = &writer{ResponseWriter: w, handle: h}
+			defer func() {
+				c.Response = w
+			}()
+
+			err := next.ServeHTTP(c)
+			if cerr := h.commit(); cerr != nil && err == nil {
+				err = cerr
+			}
+			return err
+		})
+	}
+}
+
+// Get returns the session of the current request. The session is
+// loaded from the store on the first call or created, if the client
+// has no valid session.
+func Get(c *lungo.Context) (*Session, error) {
+	h, ok := c.Get(lungo.SessionKey).(*handle)
+	if !ok {
+		return nil, ErrMiddlewareMissing
+	}
+	return h.load()
+}
+
+// handle loads and saves the session of a request.
+type handle struct {
+	config    *Config
+	c         *lungo.Context
+	session   *Session
+	committed bool
+	err       error
+}
+
+// load loads the session from the store, if not loaded yet.
+func (h *handle) load() (*Session, error) {
+	if h.session != nil {
+		return h.session, nil
+	}
+
+	if cookie, err := h.c.Cookie(h.config.CookieName); err == nil && cookie.Value != "" {
+		id, values, err := h.config.Store.Load(cookie.Value)
+		if err == nil {
+			h.session = &Session{id: id, values: values}
+			return h.session, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	s, err := newSession()
+	if err != nil {
+		return nil, err
+	}
+	h.session = s
+	return s, nil
+}
+
+// commit saves the session and sets the session cookie, if
+// the session was modified. It is executed at most once.
+func (h *handle) commit() error {
+	if h.committed {
+		return h.err
+	}
+	h.committed = true
+
+	s := h.session
+	if s == nil {
+		return nil
+	}
+
+	if s.destroyed {
+		if !s.isNew {
+			if h.err = h.config.Store.Delete(s.id); h.err != nil {
+				return h.err
+			}
+		}
+		h.setCookie("", -1)
+		return nil
+	}
+
+	if !s.modified {
+		return nil
+	}
+
+	if s.regenerate {
+		if !s.isNew {
+			if h.err = h.config.Store.Delete(s.id); h.err != nil {
+				return h.err
+			}
+		}
+		if s.id, h.err = generateID(); h.err != nil {
+			return h.err
+		}
+	}
+
+	value, err := h.config.Store.Save(s.id, s.values, time.Duration(h.config.MaxAge)*time.Second)
+	if err != nil {
+		h.err = err
+		return err
+	}
+	h.setCookie(value, h.config.MaxAge)
+	return nil
+}
+
+// setCookie sets the session cookie with the value and max age.
+func (h *handle) setCookie(value string, maxAge int) {
+	h.c.SetCookie(&http.Cookie{
+		Name:     h.config.CookieName,
+		Value:    value,
+		Domain:   h.config.CookieDomain,
+		Path:     h.config.CookiePath,
+		MaxAge:   maxAge,
+		Secure:   h.config.CookieSecure,
+		HttpOnly: h.config.CookieHTTPOnly,
+		SameSite: h.config.CookieSameSite,
+	})
+}
@@ -0,0 +1,224 @@
+package session
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+// serve dispatches a request with the cookies to the middleware
+// and returns the response recorder.
+func serve(t *testing.T, middleware lungo.Middleware, handler lungo.HandlerFunc, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	if err := middleware(handler).ServeHTTP(c); err != nil {
+		t.Fatal(err)
+	}
+	return rr
+}
+
+// cookie returns the session cookie of the response or nil if not set.
+func cookie(rr *httptest.ResponseRecorder) *http.Cookie {
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == "session" {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestSession(t *testing.T) {
+	for _, store := range []Store{NewMemoryStore(), NewCookieStore([]byte("secret"))} {
+		middleware := New(func(c *Config) {
+			c.Store = store
+		})
+
+		// The session is not saved, if it is not accessed.
+		rr := serve(t, middleware, func(c *lungo.Context) error {
+			return c.NoContent()
+		})
+		assertEqual(t, (*http.Cookie)(nil), cookie(rr))
+
+		// The session is not saved, if it is not modified.
+		rr = serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			assertEqual(t, true, s.IsNew())
+			return c.NoContent()
+		})
+		assertEqual(t, (*http.Cookie)(nil), cookie(rr))
+
+		// The session is saved before the response is written.
+		var id string
+		rr = serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			id = s.ID()
+			s.Set("user", "foo")
+			s.AddFlash("Hello")
+			return c.Text(http.StatusOK, "OK")
+		})
+		session := cookie(rr)
+		assertEqual(t, true, session != nil)
+		assertEqual(t, true, session.HttpOnly)
+		assertEqual(t, 86400, session.MaxAge)
+
+		// The values and flashes are kept across requests.
+		rr = serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			assertEqual(t, false, s.IsNew())
+			assertEqual(t, id, s.ID())
+			assertEqual(t, "foo", s.Get("user"))
+			assertEqual(t, []any{"Hello"}, s.Flashes())
+			assertEqual(t, []any(nil), s.Flashes())
+			return nil
+		}, session)
+		session = cookie(rr)
+
+		// The ID is changed on regeneration, while the values are kept.
+		rr = serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			assertEqual(t, []any(nil), s.Flashes())
+			s.Regenerate()
+			return nil
+		}, session)
+		regenerated := cookie(rr)
+
+		serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			assertEqual(t, false, id == s.ID())
+			assertEqual(t, "foo", s.Get("user"))
+			return nil
+		}, regenerated)
+
+		// The session is destroyed and the cookie expired.
+		rr = serve(t, middleware, func(c *lungo.Context) error {
+			s, err := Get(c)
+			if err != nil {
+				return err
+			}
+			s.Destroy()
+			return nil
+		}, regenerated)
+		assertEqual(t, -1, cookie(rr).MaxAge)
+	}
+}
+
+func TestSessionFixation(t *testing.T) {
+	store := NewMemoryStore()
+	middleware := New(func(c *Config) {
+		c.Store = store
+	})
+
+	rr := serve(t, middleware, func(c *lungo.Context) error {
+		s, err := Get(c)
+		if err != nil {
+			return err
+		}
+		s.Set("user", "foo")
+		return nil
+	})
+	old := cookie(rr)
+
+	rr = serve(t, middleware, func(c *lungo.Context) error {
+		s, err := Get(c)
+		if err != nil {
+			return err
+		}
+		s.Regenerate()
+		return nil
+	}, old)
+	assertEqual(t, false, old.Value == cookie(rr).Value)
+
+	// The previous ID is no longer valid.
+	serve(t, middleware, func(c *lungo.Context) error {
+		s, err := Get(c)
+		if err != nil {
+			return err
+		}
+		assertEqual(t, true, s.IsNew())
+		assertEqual(t, nil, s.Get("user"))
+		return nil
+	}, old)
+	assertEqual(t, 1, store.Len())
+}
+
+func TestSessionMiddlewareMissing(t *testing.T) {
+	app := lungo.New()
+	c := app.NewContext(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	_, err := Get(c)
+	assertEqual(t, ErrMiddlewareMissing, err)
+}
+
+type hijacker struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (h *hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	return nil, nil, nil
+}
+
+func TestSessionHijack(t *testing.T) {
+	store := NewMemoryStore()
+	middleware := New(func(c *Config) {
+		c.Store = store
+	})
+
+	handler := lungo.HandlerFunc(func(c *lungo.Context) error {
+		s, err := Get(c)
+		if err != nil {
+			return err
+		}
+		s.Set("user", "foo")
+		_, _, err = c.Hijack()
+		return err
+	})
+
+	// The session is saved before the connection is hijacked.
+	rr := &hijacker{ResponseRecorder: httptest.NewRecorder()}
+	c := lungo.New().NewContext(rr, httptest.NewRequest("GET", "/", nil))
+	assertEqual(t, nil, middleware(handler).ServeHTTP(c))
+	assertEqual(t, true, rr.hijacked)
+	assertEqual(t, 1, store.Len())
+
+	// Hijacking fails, if the response does not support it.
+	c = lungo.New().NewContext(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	err := middleware(handler).ServeHTTP(c)
+	assertEqual(t, "Hijack: response does not implement http.Hijacker", err.Error())
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by a Store, if the session does not exist,
+// is expired or the value of the session cookie is invalid.
+var ErrNotFound = errors.New("Session: session not found")
+
+// Store defines the interface of the storage of sessions.
+type Store interface {
+	// Load returns the ID and the values of the session
+	// referenced by the value of the session cookie.
+	Load(value string) (id string, values map[string]any, err error)
+
+	// Save stores the values of the session for the duration
+	// of ttl and returns the value of the session cookie.
+	Save(id string, values map[string]any, ttl time.Duration) (value string, err error)
+
+	// Delete removes the session from the store.
+	Delete(id string) error
+}
+
+// memoryEntry is a session stored in the MemoryStore.
+type memoryEntry struct {
+	values  map[string]any
+	expires time.Time
+}
+
+// MemoryStore is an in-memory Store, which references sessions by their ID.
+// Expired sessions are swept at most once per minute.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]memoryEntry
+	swept    time.Time
+	now      func() time.Time
+}
+
+// NewMemoryStore creates a new in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]memoryEntry), now: time.Now}
+}
+
+// Load implements the Store interface.
+func (s *MemoryStore) Load(value string) (string, map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.sessions[value]
+	if !ok || !s.now().Before(e.expires) {
+		return "", nil, ErrNotFound
+	}
+	return value, copyValues(e.values), nil
+}
+
+// Save implements the Store interface.
+func (s *MemoryStore) Save(id string, values map[string]any, ttl time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sessions[id] = memoryEntry{values: copyValues(values), expires: now.Add(ttl)}
+
+	if now.Sub(s.swept) >= time.Minute {
+		s.swept = now
+		for k, e := range s.sessions {
+			if !now.Before(e.expires) {
+				delete(s.sessions, k)
+			}
+		}
+	}
+	return id, nil
+}
+
+// Delete implements the Store interface.
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return nil
+}
+
+// Len returns the number of sessions in the store, including expired sessions.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// copyValues returns a shallow copy of the values.
+func copyValues(values map[string]any) map[string]any {
+	c := make(map[string]any, len(values))
+	for k, v := range values {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	_, _, err := s.Load("foo")
+	assertEqual(t, ErrNotFound, err)
+
+	values := map[string]any{"foo": "bar"}
+	value, err := s.Save("foo", values, time.Minute)
+	assertEqual(t, nil, err)
+	assertEqual(t, "foo", value)
+
+	// The stored values are independent of the session.
+	values["foo"] = "baz"
+	id, loaded, err := s.Load("foo")
+	assertEqual(t, nil, err)
+	assertEqual(t, "foo", id)
+	assertEqual(t, map[string]any{"foo": "bar"}, loaded)
+
+	now = now.Add(time.Minute)
+	_, _, err = s.Load("foo")
+	assertEqual(t, ErrNotFound, err)
+	assertEqual(t, 1, s.Len())
+
+	// Expired sessions are removed on the next sweep.
+	_, err = s.Save("bar", values, time.Minute)
+	assertEqual(t, nil, err)
+	assertEqual(t, 1, s.Len())
+
+	assertEqual(t, nil, s.Delete("bar"))
+	assertEqual(t, 0, s.Len())
+}
+
+func TestCookieStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	old := NewCookieStore([]byte("old"))
+	old.now = func() time.Time { return now }
+	s := NewCookieStore([]byte("new"), []byte("old"))
+	s.now = func() time.Time { return now }
+
+	value, err := s.Save("foo", map[string]any{"foo": "bar", "n": 42}, time.Minute)
+	assertEqual(t, nil, err)
+
+	id, values, err := s.Load(value)
+	assertEqual(t, nil, err)
+	assertEqual(t, "foo", id)
+	assertEqual(t, map[string]any{"foo": "bar", "n": 42}, values)
+
+	// Sessions encoded with a rotated secret are still accepted.
+	value, err = old.Save("foo", map[string]any{"foo": "bar"}, time.Minute)
+	assertEqual(t, nil, err)
+	_, values, err = s.Load(value)
+	assertEqual(t, nil, err)
+	assertEqual(t, map[string]any{"foo": "bar"}, values)
+
+	// Sessions encoded with an unknown secret are rejected.
+	value, err = s.Save("foo", map[string]any{"foo": "bar"}, time.Minute)
+	assertEqual(t, nil, err)
+	_, _, err = old.Load(value)
+	assertEqual(t, ErrNotFound, err)
+
+	// Tampered sessions are rejected.
+	tampered := []byte(value)
+	tampered[10] ^= 1
+	_, _, err = s.Load(string(tampered))
+	assertEqual(t, ErrNotFound, err)
+
+	for _, v := range []string{"", "invalid", "!"} {
+		_, _, err = s.Load(v)
+		assertEqual(t, ErrNotFound, err)
+	}
+
+	// Expired sessions are rejected.
+	now = now.Add(time.Minute)
+	_, _, err = s.Load(value)
+	assertEqual(t, ErrNotFound, err)
+
+	_, err = s.Save("foo", map[string]any{"foo": strings.Repeat("a", maxCookieSize)}, time.Minute)
+	assertEqual(t, ErrCookieTooLarge, err)
+}
+
+func TestCookieStoreMissingSecret(t *testing.T) {
+	defer func() {
+		assertEqual(t, true, recover() != nil)
+	}()
+	NewCookieStore()
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// writer is a http.ResponseWriter, which saves the session
+// before the header of the response is written.
+type writer struct {
+	http.ResponseWriter
+	handle *handle
+}
+
+// WriteHeader saves the session and writes the header.
+func (w *writer) WriteHeader(code int) {
+	_ = w.handle.commit()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements the io.Writer interface.
+func (w *writer) Write(b []byte) (int, error) {
+	_ = w.handle.commit()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements the http.Flusher interface.
+func (w *writer) Flush() {
+	_ = w.handle.commit()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack saves the session and implements the http.Hijacker interface.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if err := w.handle.commit(); err != nil {
+		return nil, nil, err
+	}
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("Hijack: response does not implement http.Hijacker")
+}
@@ -1,8 +1,75 @@
 package template
 
-// Config contains the configuration for the middleware
-type Config struct{}
+import (
+	"html/template"
+	"io/fs"
+)
+
+// Config defines the configuration options for the template middleware
+type Config struct {
+	// Directory defines the directory on disk to load the templates from.
+	// It is ignored if FS is set.
+	//
+	// Optional. Default value "views".
+	Directory string
+
+	// FS defines the file system to load the templates from, e.g. an embed.FS.
+	//
+	// Optional. Default value nil.
+	FS fs.FS
+
+	// Extension defines the file extension of the templates. Templates are
+	// named by their path relative to the root without the extension,
+	// e.g. the file "users/index.html" is rendered with `c.Render(200, "users/index", data)`.
+	//
+	// Optional. Default value ".html".
+	Extension string
+
+	// Layout defines the name of the layout template to render views in.
+	// The layout renders the view by calling `{{template "content" .}}`.
+	// Views are rendered on their own, if no layout is set.
+	//
+	// Optional. Default value "".
+	Layout string
+
+	// Layouts defines the directory containing the layout templates.
+	// Layouts are available to every view.
+	//
+	// Optional. Default value "layouts".
+	Layouts string
+
+	// Partials defines the directory containing the partial templates.
+	// Partials are available to every view and layout, e.g. by calling
+	// `{{template "partials/header" .}}`.
+	//
+	// Optional. Default value "partials".
+	Partials string
+
+	// Funcs defines additional functions, which are available to all templates.
+	//
+	// see: https://golang.org/pkg/html/template/#FuncMap
+	//
+	// Optional. Default value nil.
+	Funcs template.FuncMap
+
+	// Reload defines whether or not the templates are reloaded from the file
+	// system on every render. This is useful during development to see changes
+	// without restarting the server. Otherwise the templates are loaded once
+	// and cached.
+	//
+	// Optional. Default value false.
+	Reload bool
+}
 
 // DefaultConfig contains the default value for the
-// middleware configuration
-var DefaultConfig = &Config{}
+// template middleware configuration
+var DefaultConfig = &Config{
+	Directory: "views",
+	FS:        nil,
+	Extension: ".html",
+	Layout:    "",
+	Layouts:   "layouts",
+	Partials:  "partials",
+	Funcs:     nil,
+	Reload:    false,
+}
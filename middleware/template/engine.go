@@ -0,0 +1,133 @@
+package template
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// contentName is the name of the template,
+// which is used by layouts to render the view.
+const contentName = "content"
+
+// Engine loads and renders html/template sets.
+// It implements the lungo.Renderer interface.
+type Engine struct {
+	mutex  sync.RWMutex
+	config *Config
+	views  map[string]*template.Template
+}
+
+// NewEngine creates a new Engine instance.
+// The templates are loaded lazily on the first render.
+func NewEngine(config *Config) *Engine {
+	return &Engine{config: config}
+}
+
+// Load (re-)loads all templates from the file system.
+//
+// Every view is parsed into its own template set together
+// with all layouts and partials, such that views can
+// define blocks with the same name.
+func (e *Engine) Load() error {
+	fsys := e.config.FS
+	if fsys == nil {
+		fsys = os.DirFS(e.config.Directory)
+	}
+
+	shared := template.New("").Funcs(e.config.Funcs)
+	sources := make(map[string]string)
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != e.config.Extension {
+			return nil
+		}
+
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(p, e.config.Extension)
+		if e.isShared(name) {
+			_, err = shared.New(name).Parse(string(b))
+			return err
+		}
+
+		sources[name] = string(b)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	views := make(map[string]*template.Template, len(sources))
+	for name, src := range sources {
+		t, err := shared.Clone()
+		if err != nil {
+			return err
+		}
+		if _, err = t.New(name).Parse(src); err != nil {
+			return err
+		}
+		if e.config.Layout != "" {
+			if _, err = t.New(contentName).Parse(src); err != nil {
+				return err
+			}
+		}
+		views[name] = t
+	}
+
+	e.mutex.Lock()
+	e.views = views
+	e.mutex.Unlock()
+
+	return nil
+}
+
+// Render writes the view with the given name to w.
+// The view is rendered inside of the layout, if configured.
+func (e *Engine) Render(w io.Writer, name string, data any) error {
+	e.mutex.RLock()
+	loaded := e.views != nil
+	e.mutex.RUnlock()
+
+	if e.config.Reload || !loaded {
+		if err := e.Load(); err != nil {
+			return err
+		}
+	}
+
+	e.mutex.RLock()
+	t, ok := e.views[name]
+	e.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("Render: template %q is undefined", name)
+	}
+
+	if e.config.Layout != "" {
+		return t.ExecuteTemplate(w, e.config.Layout, data)
+	}
+
+	return t.ExecuteTemplate(w, name, data)
+}
+
+// isShared reports whether the template with the given
+// name is a layout or partial.
+func (e *Engine) isShared(name string) bool {
+	for _, dir := range []string{e.config.Layouts, e.config.Partials} {
+		if dir != "" && strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package template
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var views = fstest.MapFS{
+	"layouts/main.html":   {Data: []byte(`<title>{{block "title" .}}Default{{end}}</title>{{template "content" .}}`)},
+	"partials/name.html":  {Data: []byte(`<b>{{.}}</b>`)},
+	"index.html":          {Data: []byte(`Hello, {{template "partials/name" .Name}}!`)},
+	"users/show.html":     {Data: []byte(`{{define "title"}}User{{end}}{{upper .Name}}`)},
+	"users/ignored.txt":   {Data: []byte(`ignored`)},
+	"users/escaped.html":  {Data: []byte(`{{.Name}}`)},
+	"partials/other.html": {Data: []byte(`other`)},
+}
+
+func TestEngine(t *testing.T) {
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+
+	var tests = []struct {
+		layout string
+		name   string
+		data   any
+		body   string
+		err    bool
+	}{
+		{name: "index", data: map[string]string{"Name": "World"}, body: "Hello, <b>World</b>!"},
+		{name: "users/show", data: map[string]string{"Name": "john"}, body: "JOHN"},
+		{name: "users/escaped", data: map[string]string{"Name": "<script>"}, body: "&lt;script&gt;"},
+		{layout: "layouts/main", name: "index", data: map[string]string{"Name": "World"}, body: "<title>Default</title>Hello, <b>World</b>!"},
+		{layout: "layouts/main", name: "users/show", data: map[string]string{"Name": "john"}, body: "<title>User</title>JOHN"},
+		{name: "users/ignored", err: true},
+		{name: "partials/name", err: true},
+		{name: "missing", err: true},
+	}
+
+	for _, testcase := range tests {
+		e := NewEngine(&Config{
+			FS:        views,
+			Extension: ".html",
+			Layout:    testcase.layout,
+			Layouts:   "layouts",
+			Partials:  "partials",
+			Funcs:     funcs,
+		})
+
+		var b bytes.Buffer
+		err := e.Render(&b, testcase.name, testcase.data)
+
+		assertEqual(t, testcase.err, err != nil)
+		assertEqual(t, testcase.body, b.String())
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "index.html")
+
+	if err := os.WriteFile(file, []byte("foo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, reload := range []bool{false, true} {
+		if err := os.WriteFile(file, []byte("foo"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		e := NewEngine(&Config{Directory: dir, Extension: ".html", Reload: reload})
+
+		var b bytes.Buffer
+		if err := e.Render(&b, "index", nil); err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(t, "foo", b.String())
+
+		if err := os.WriteFile(file, []byte("bar"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		b.Reset()
+		if err := e.Render(&b, "index", nil); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "foo"
+		if reload {
+			expected = "bar"
+		}
+		assertEqual(t, expected, b.String())
+	}
+}
+
+func TestEngineInvalidTemplate(t *testing.T) {
+	e := NewEngine(&Config{
+		FS:        fstest.MapFS{"index.html": {Data: []byte("{{.Name")}},
+		Extension: ".html",
+	})
+	assertEqual(t, true, e.Load() != nil)
+
+	e = NewEngine(&Config{Directory: "missing", Extension: ".html"})
+	assertEqual(t, true, e.Load() != nil)
+}
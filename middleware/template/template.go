@@ -13,8 +13,11 @@ func New(configure ...func(*Config)) lungo.Middleware {
 		c(config)
 	}
 
+	engine := NewEngine(config)
+
 	return func(next lungo.Handler) lungo.Handler {
 		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			c.Set(lungo.RendererKey, engine)
 			return next.ServeHTTP(c)
 		})
 	}
@@ -1,9 +1,11 @@
 package template
 
 import (
+	"html/template"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/felix-kaestner/lungo"
@@ -20,17 +22,27 @@ func assertEqual(t *testing.T, expected, actual any) {
 func TestTemplate(t *testing.T) {
 	var tests = []struct {
 		middleware lungo.Middleware
-		eval       func(h http.Header)
+		eval       func(rr *httptest.ResponseRecorder, err error)
 	}{
 		{
 			middleware: New(),
-			eval:       func(h http.Header) {},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, true, err != nil)
+				assertEqual(t, "", rr.Body.String())
+			},
 		},
 		{
 			middleware: New(func(c *Config) {
-
+				c.FS = views
+				c.Funcs = template.FuncMap{"upper": strings.ToUpper}
+				c.Layout = "layouts/main"
 			}),
-			eval: func(h http.Header) {},
+			eval: func(rr *httptest.ResponseRecorder, err error) {
+				assertEqual(t, nil, err)
+				assertEqual(t, http.StatusCreated, rr.Code)
+				assertEqual(t, lungo.MIMETextHTMLCharsetUTF8, rr.Header().Get(lungo.HeaderContentType))
+				assertEqual(t, "<title>Default</title>Hello, <b>World</b>!", rr.Body.String())
+			},
 		},
 	}
 
@@ -44,9 +56,10 @@ func TestTemplate(t *testing.T) {
 		app := lungo.New()
 		c := app.NewContext(rr, req)
 
-		h := testcase.middleware(lungo.NotFoundHandler())
-		h.ServeHTTP(c)
+		h := testcase.middleware(lungo.HandlerFunc(func(c *lungo.Context) error {
+			return c.Render(http.StatusCreated, "index", lungo.Map{"Name": "World"})
+		}))
 
-		testcase.eval(rr.Header())
+		testcase.eval(rr, h.ServeHTTP(c))
 	}
 }
@@ -0,0 +1,36 @@
+package timeout
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config defines the configuration options for the timeout middleware
+type Config struct {
+	// Timeout defines the maximum duration of the handling of a request.
+	//
+	// Optional. Default: 10 * time.Second
+	Timeout time.Duration
+
+	// Code defines the status code of the response, if the
+	// handler does not finish within the Timeout, e.g.
+	// http.StatusServiceUnavailable or http.StatusGatewayTimeout.
+	//
+	// Optional. Default: http.StatusServiceUnavailable
+	Code int
+
+	// Message defines the body of the response, if the handler
+	// does not finish within the Timeout. If empty, the status
+	// text of the Code is used.
+	//
+	// Optional. Default: ""
+	Message string
+}
+
+// DefaultConfig contains the default value for the
+// timeout middleware configuration
+var DefaultConfig = &Config{
+	Timeout: 10 * time.Second,
+	Code:    http.StatusServiceUnavailable,
+	Message: "",
+}
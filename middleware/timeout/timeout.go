@@ -0,0 +1,86 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+// New creates a new timeout middleware instance
+//
+// The handler is run in a separate goroutine with a copy of the Context, whose
+// request context is canceled after the Timeout. Its response is buffered and
+// only written once the handler finishes in time, thus streaming responses
+// are not supported: `c.Flush()` has no effect and `c.Hijack()` fails.
+func New(configure ...func(*Config)) lungo.Middleware {
+	config := new(Config)
+	*config = *DefaultConfig
+
+	for _, c := range configure {
+		c(config)
+	}
+
+	message := config.Message
+	if message == "" {
+		message = http.StatusText(config.Code)
+	}
+
+	return func(next lungo.Handler) lungo.Handler {
+		return lungo.HandlerFunc(func(c *lungo.Context) error {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), config.Timeout)
+			defer cancel()
+
+			w := &writer{header: make(http.Header)}
+
+			cc := c.Copy()
+			cc.Request = c.Request.WithContext(ctx)
+			cc.Response = w
+
+			done := make(chan error, 1)
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				done <- next.ServeHTTP(cc)
+			}()
+
+			select {
+			case p := <-panicked:
+				// Propagate the panic to the goroutine of the request,
+				// such that it can be handled by the recover middleware.
+				panic(p)
+			case err := <-done:
+				w.mu.Lock()
+				defer w.mu.Unlock()
+
+				header := c.Response.Header()
+				for k, v := range w.header {
+					header[k] = v
+				}
+				if w.code != 0 {
+					c.WriteHeader(w.code)
+				}
+				if len(w.buf) > 0 {
+					if _, werr := c.Response.Write(w.buf); werr != nil && err == nil {
+						err = werr
+					}
+				}
+				return err
+			case <-ctx.Done():
+				w.mu.Lock()
+				defer w.mu.Unlock()
+
+				w.timedOut = true
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return c.Errorf(config.Code, message)
+				}
+				return ctx.Err()
+			}
+		})
+	}
+}
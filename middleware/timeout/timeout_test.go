@@ -0,0 +1,198 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/felix-kaestner/lungo"
+)
+
+func assertEqual(t *testing.T, expected, actual any) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	t.Errorf("Test %s: Expected `%v` (type %v), Received `%v` (type %v)", t.Name(), expected, reflect.TypeOf(expected), actual, reflect.TypeOf(actual))
+}
+
+func TestTimeout(t *testing.T) {
+	// block is closed once the test finished, such that the
+	// abandoned handlers only return after the request timed out.
+	block := make(chan struct{})
+	defer close(block)
+
+	var tests = []struct {
+		middleware lungo.Middleware
+		handler    lungo.HandlerFunc
+		code       int
+		body       string
+		header     string
+	}{
+		{
+			middleware: New(),
+			handler: func(c *lungo.Context) error {
+				c.SetHeader("X-Foo", "bar")
+				return c.Text(http.StatusCreated, "Hello World")
+			},
+			code:   http.StatusCreated,
+			body:   "Hello World",
+			header: "bar",
+		},
+		{
+			middleware: New(),
+			handler: func(c *lungo.Context) error {
+				return c.Error(http.StatusBadRequest)
+			},
+			code: http.StatusBadRequest,
+			body: "Bad Request\n",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Timeout = 10 * time.Millisecond
+			}),
+			handler: func(c *lungo.Context) error {
+				c.SetHeader("X-Foo", "bar")
+				<-block
+				return c.Text(http.StatusOK, "Hello World")
+			},
+			code: http.StatusServiceUnavailable,
+			body: "Service Unavailable\n",
+		},
+		{
+			middleware: New(func(c *Config) {
+				c.Timeout = 10 * time.Millisecond
+				c.Code = http.StatusGatewayTimeout
+				c.Message = "Timeout"
+			}),
+			handler: func(c *lungo.Context) error {
+				<-block
+				return nil
+			},
+			code: http.StatusGatewayTimeout,
+			body: "Timeout\n",
+		},
+	}
+
+	for _, testcase := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		app := lungo.New()
+		c := app.NewContext(rr, req)
+
+		h := testcase.middleware(testcase.handler)
+		if err := h.ServeHTTP(c); err != nil {
+			app.HandleError(c, err)
+		}
+
+		assertEqual(t, testcase.code, rr.Code)
+		assertEqual(t, testcase.body, rr.Body.String())
+		assertEqual(t, testcase.header, rr.Header().Get("X-Foo"))
+	}
+}
+
+func TestTimeoutLateWrite(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	timedOut := make(chan struct{})
+	written := make(chan error, 1)
+	h := New(func(c *Config) {
+		c.Timeout = 10 * time.Millisecond
+	})(lungo.HandlerFunc(func(c *lungo.Context) error {
+		<-timedOut
+		_, err := c.Response.Write([]byte("Hello World"))
+		written <- err
+		return err
+	}))
+
+	err = h.ServeHTTP(c)
+	close(timedOut)
+
+	assertEqual(t, &lungo.RequestError{Code: http.StatusServiceUnavailable, Message: "Service Unavailable"}, err)
+	assertEqual(t, http.ErrHandlerTimeout, <-written)
+	assertEqual(t, "", rr.Body.String())
+}
+
+func TestTimeoutCanceled(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	req = req.WithContext(ctx)
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		cancel()
+		<-block
+		return nil
+	}))
+
+	assertEqual(t, context.Canceled, h.ServeHTTP(c))
+}
+
+func TestTimeoutStreaming(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		c.Text(http.StatusOK, "Hello ")
+		c.Flush()
+		if _, _, err := c.Hijack(); err == nil {
+			return errors.New("Hijack: expected error")
+		}
+		_, err := c.Response.Write([]byte("World"))
+		return err
+	}))
+
+	assertEqual(t, nil, h.ServeHTTP(c))
+	assertEqual(t, false, rr.Flushed)
+	assertEqual(t, "Hello World", rr.Body.String())
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := lungo.New()
+	c := app.NewContext(rr, req)
+
+	h := New()(lungo.HandlerFunc(func(c *lungo.Context) error {
+		panic(errors.New("Error"))
+	}))
+
+	defer func() {
+		assertEqual(t, errors.New("Error"), recover())
+	}()
+	h.ServeHTTP(c)
+}
@@ -0,0 +1,61 @@
+package timeout
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// writer is a http.ResponseWriter, which buffers the response
+// of the handler until it finishes. Once the request timed out,
+// writes of the abandoned handler fail with http.ErrHandlerTimeout.
+type writer struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      []byte
+	code     int
+	timedOut bool
+}
+
+// Header implements the http.ResponseWriter interface.
+func (w *writer) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader records the status code of the response.
+func (w *writer) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut || w.code != 0 {
+		return
+	}
+	w.code = code
+}
+
+// Write implements the io.Writer interface.
+func (w *writer) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.buf = append(w.buf, b...)
+	return len(b), nil
+}
+
+// Flush implements the http.Flusher interface. It is a no-op, since
+// the response is only written once the handler finishes.
+func (w *writer) Flush() {}
+
+// Hijack implements the http.Hijacker interface. It always fails,
+// since the connection may not be taken over by the handler.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("Hijack: response does not support hijacking within a timeout")
+}
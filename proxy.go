@@ -0,0 +1,230 @@
+package lungo
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// forwardedHop contains the information about a request
+// as received by a proxy, see the `Forwarded` header.
+type forwardedHop struct {
+	addr  string
+	proto string
+	host  string
+}
+
+// parseTrustedProxies parses the addresses and CIDR ranges of the
+// trusted proxies. It panics if any of them is invalid.
+func parseTrustedProxies(proxies []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		if strings.Contains(p, "/") {
+			prefix, err := netip.ParsePrefix(p)
+			if err != nil {
+				panic(fmt.Sprintf("Invalid trusted proxy. `%s` is not a valid CIDR range.", p))
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(p)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid trusted proxy. `%s` is not a valid address.", p))
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes
+}
+
+// parseProxyHeader returns the canonical form of the proxy header.
+// It panics if the header is not supported.
+func parseProxyHeader(header string) string {
+	switch h := http.CanonicalHeaderKey(header); h {
+	case HeaderForwarded, HeaderXForwardedFor:
+		return h
+	case "X-Real-Ip":
+		return HeaderXRealIP
+	}
+	panic(fmt.Sprintf("Invalid proxy header. `%s` must be one of `%s`, `%s` or `%s`.", header, HeaderXForwardedFor, HeaderXRealIP, HeaderForwarded))
+}
+
+// isTrustedProxy reports whether the address belongs to a trusted proxy.
+func (app *App) isTrustedProxy(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, p := range app.trustedProxies {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RealIP returns the IP address of the client. If the request was received
+// from a trusted proxy, the client address is resolved from the ProxyHeader
+// of the Config. The `Forwarded` or `X-Forwarded-For` header is walked from
+// right to left, skipping trusted proxies, and the first untrusted address
+// is returned. Otherwise, the address of the remote end of the connection
+// is returned.
+func (c *Context) RealIP() string {
+	remote := remoteAddr(c.Request.RemoteAddr)
+	if !c.App.isTrustedProxy(remote) {
+		return remote
+	}
+
+	if hop, ok := c.clientHop(); ok {
+		return hop.addr
+	}
+	return remote
+}
+
+// Scheme returns the scheme of the request as received by the application
+// or, if the request was received from a trusted proxy, by the outermost
+// trusted proxy, i.e. "http" or "https". The scheme is resolved from the
+// `Forwarded` header, if it is the ProxyHeader of the Config, and from the
+// `X-Forwarded-Proto` header otherwise.
+func (c *Context) Scheme() string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	if !c.App.isTrustedProxy(remoteAddr(c.Request.RemoteAddr)) {
+		return scheme
+	}
+
+	if c.App.config.ProxyHeader == HeaderForwarded {
+		if hop, ok := c.clientHop(); ok && hop.proto != "" {
+			return strings.ToLower(hop.proto)
+		}
+	} else if proto := lastValue(c.Header(HeaderXForwardedProto)); proto != "" {
+		return strings.ToLower(proto)
+	}
+	return scheme
+}
+
+// Host returns the host of the request as received by the application
+// or, if the request was received from a trusted proxy, by the outermost
+// trusted proxy. The host is resolved from the `Forwarded` header, if it
+// is the ProxyHeader of the Config, and from the `X-Forwarded-Host`
+// header otherwise.
+func (c *Context) Host() string {
+	if !c.App.isTrustedProxy(remoteAddr(c.Request.RemoteAddr)) {
+		return c.Request.Host
+	}
+
+	if c.App.config.ProxyHeader == HeaderForwarded {
+		if hop, ok := c.clientHop(); ok && hop.host != "" {
+			return hop.host
+		}
+	} else if host := lastValue(c.Header(HeaderXForwardedHost)); host != "" {
+		return host
+	}
+	return c.Request.Host
+}
+
+// clientHop returns the hop of the ProxyHeader of the Config, which
+// describes the request received from the client by the outermost
+// trusted proxy. The caller must ensure, that the request was received
+// from a trusted proxy.
+func (c *Context) clientHop() (forwardedHop, bool) {
+	var hops []forwardedHop
+	switch c.App.config.ProxyHeader {
+	case HeaderForwarded:
+		hops = parseForwarded(c.Request.Header.Values(HeaderForwarded))
+	case HeaderXForwardedFor:
+		for _, v := range c.Request.Header.Values(HeaderXForwardedFor) {
+			for _, addr := range strings.Split(v, ",") {
+				hops = append(hops, forwardedHop{addr: strings.TrimSpace(addr)})
+			}
+		}
+	case HeaderXRealIP:
+		// The header is set by the proxy closest to the application.
+		addr := strings.TrimSpace(c.Header(HeaderXRealIP))
+		if _, err := netip.ParseAddr(addr); err != nil {
+			return forwardedHop{}, false
+		}
+		return forwardedHop{addr: addr}, true
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr := hops[i].addr
+		if _, err := netip.ParseAddr(addr); err != nil {
+			// Unknown and obfuscated addresses can not be resolved any further.
+			return forwardedHop{}, false
+		}
+		if i == 0 || !c.App.isTrustedProxy(addr) {
+			return hops[i], true
+		}
+	}
+	return forwardedHop{}, false
+}
+
+// parseForwarded parses the values of the `Forwarded` header.
+//
+// see: https://datatracker.ietf.org/doc/html/rfc7239
+func parseForwarded(values []string) []forwardedHop {
+	hops := make([]forwardedHop, 0)
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			var hop forwardedHop
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+				value = strings.Trim(value, `"`)
+				switch strings.ToLower(key) {
+				case "for":
+					hop.addr = forwardedAddr(value)
+				case "proto":
+					hop.proto = value
+				case "host":
+					hop.host = value
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// forwardedAddr returns the address of a node of the `Forwarded` header,
+// e.g. "192.0.2.43", "192.0.2.43:47011" or "[2001:db8:cafe::17]:4711".
+func forwardedAddr(node string) string {
+	if strings.HasPrefix(node, "[") {
+		if i := strings.IndexByte(node, ']'); i > 0 {
+			return node[1:i]
+		}
+		return node
+	}
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		return host
+	}
+	return node
+}
+
+// remoteAddr returns the host of the network address of the remote end.
+func remoteAddr(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// lastValue returns the last element of a comma-separated header value,
+// which was set by the proxy closest to the application.
+func lastValue(v string) string {
+	if i := strings.LastIndexByte(v, ','); i >= 0 {
+		v = v[i+1:]
+	}
+	return strings.TrimSpace(v)
+}
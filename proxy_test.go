@@ -0,0 +1,237 @@
+package lungo
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextRealIP(t *testing.T) {
+	var tests = []struct {
+		proxyHeader string
+		remoteAddr  string
+		header      http.Header
+		ip          string
+	}{
+		{remoteAddr: "192.0.2.1:1234", ip: "192.0.2.1"},
+		{remoteAddr: "192.0.2.1", ip: "192.0.2.1"},
+		{
+			// Headers of untrusted clients are ignored.
+			remoteAddr: "192.0.2.1:1234",
+			header:     http.Header{HeaderXForwardedFor: {"203.0.113.1"}, HeaderXRealIP: {"203.0.113.2"}},
+			ip:         "192.0.2.1",
+		},
+		{remoteAddr: "10.0.0.1:1234", ip: "10.0.0.1"},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedFor: {"203.0.113.1"}},
+			ip:         "203.0.113.1",
+		},
+		{
+			// Spoofed addresses left of the client are ignored.
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedFor: {"1.1.1.1, 203.0.113.1, 10.0.0.2", "10.0.0.3"}},
+			ip:         "203.0.113.1",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedFor: {"10.0.0.3, 10.0.0.2"}},
+			ip:         "10.0.0.3",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedFor: {"invalid, 10.0.0.2"}, HeaderXRealIP: {"203.0.113.2"}},
+			ip:         "10.0.0.1",
+		},
+		{
+			// Headers other than the proxy header are ignored, since they
+			// may be passed through unchanged by the proxy.
+			remoteAddr: "10.0.0.1:1234",
+			header: http.Header{
+				HeaderForwarded:     {"for=1.2.3.4;proto=https;host=evil.com"},
+				HeaderXRealIP:       {"1.2.3.5"},
+				HeaderXForwardedFor: {"203.0.113.1"},
+			},
+			ip: "203.0.113.1",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderForwarded: {"for=1.2.3.4"}, HeaderXRealIP: {"1.2.3.5"}},
+			ip:         "10.0.0.1",
+		},
+		{
+			proxyHeader: HeaderXRealIP,
+			remoteAddr:  "10.0.0.1:1234",
+			header:      http.Header{HeaderXRealIP: {"203.0.113.2"}, HeaderXForwardedFor: {"1.2.3.4"}},
+			ip:          "203.0.113.2",
+		},
+		{
+			proxyHeader: HeaderXRealIP,
+			remoteAddr:  "10.0.0.1:1234",
+			header:      http.Header{HeaderXRealIP: {"invalid"}},
+			ip:          "10.0.0.1",
+		},
+		{
+			proxyHeader: HeaderForwarded,
+			remoteAddr:  "[::1]:1234",
+			header: http.Header{
+				HeaderForwarded:     {`for=198.51.100.17;proto=https, for="[2001:db8:cafe::17]:4711"`},
+				HeaderXForwardedFor: {"203.0.113.1"},
+			},
+			ip: "198.51.100.17",
+		},
+		{
+			proxyHeader: HeaderForwarded,
+			remoteAddr:  "[::1]:1234",
+			header:      http.Header{HeaderForwarded: {`for="198.51.100.17:4711", for=unknown`}},
+			ip:          "::1",
+		},
+		{
+			proxyHeader: "forwarded",
+			remoteAddr:  "[::ffff:10.0.0.1]:1234",
+			header:      http.Header{HeaderForwarded: {`For="[2001:db9::1]"`}},
+			ip:          "2001:db9::1",
+		},
+	}
+
+	for _, testcase := range tests {
+		app := New(func(c *Config) {
+			c.TrustedProxies = []string{"10.0.0.0/8", "::1", "2001:db8::/32"}
+			if testcase.proxyHeader != "" {
+				c.ProxyHeader = testcase.proxyHeader
+			}
+		})
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = testcase.remoteAddr
+		for k, values := range testcase.header {
+			for _, v := range values {
+				req.Header.Add(k, v)
+			}
+		}
+
+		c := app.NewContext(httptest.NewRecorder(), req)
+		assertEqual(t, testcase.ip, c.RealIP())
+	}
+}
+
+func TestContextSchemeHost(t *testing.T) {
+	var tests = []struct {
+		proxyHeader string
+		remoteAddr  string
+		tls         bool
+		header      http.Header
+		scheme      string
+		host        string
+	}{
+		{remoteAddr: "192.0.2.1:1234", scheme: "http", host: "example.com"},
+		{remoteAddr: "192.0.2.1:1234", tls: true, scheme: "https", host: "example.com"},
+		{
+			remoteAddr: "192.0.2.1:1234",
+			header:     http.Header{HeaderXForwardedProto: {"https"}, HeaderXForwardedHost: {"evil.com"}},
+			scheme:     "http",
+			host:       "example.com",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedProto: {"HTTPS"}, HeaderXForwardedHost: {"foo.com"}},
+			scheme:     "https",
+			host:       "foo.com",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedProto: {"http, https"}, HeaderXForwardedHost: {"evil.com, foo.com"}},
+			scheme:     "https",
+			host:       "foo.com",
+		},
+		{
+			remoteAddr: "10.0.0.1:1234",
+			header:     http.Header{HeaderXForwardedProtocol: {"https"}, HeaderXForwardedSsl: {"on"}, HeaderXUrlScheme: {"https"}},
+			scheme:     "http",
+			host:       "example.com",
+		},
+		{
+			// A Forwarded header injected by the client is ignored behind a
+			// proxy, which only sets the X-Forwarded-* headers.
+			remoteAddr: "10.0.0.1:1234",
+			header: http.Header{
+				HeaderForwarded:       {"for=1.2.3.4;proto=https;host=evil.com"},
+				HeaderXForwardedProto: {"http"},
+			},
+			scheme: "http",
+			host:   "example.com",
+		},
+		{
+			proxyHeader: HeaderXRealIP,
+			remoteAddr:  "10.0.0.1:1234",
+			header:      http.Header{HeaderXForwardedProto: {"https"}, HeaderXForwardedHost: {"foo.com"}},
+			scheme:      "https",
+			host:        "foo.com",
+		},
+		{
+			proxyHeader: HeaderForwarded,
+			remoteAddr:  "10.0.0.1:1234",
+			header: http.Header{
+				HeaderForwarded:       {`for=192.0.2.60;proto=https;host=foo.com, for=10.0.0.2;proto=http;host=internal`},
+				HeaderXForwardedProto: {"http"},
+				HeaderXForwardedHost:  {"evil.com"},
+			},
+			scheme: "https",
+			host:   "foo.com",
+		},
+		{
+			proxyHeader: HeaderForwarded,
+			remoteAddr:  "10.0.0.1:1234",
+			header:      http.Header{HeaderXForwardedProto: {"https"}, HeaderXForwardedHost: {"evil.com"}},
+			scheme:      "http",
+			host:        "example.com",
+		},
+	}
+
+	for _, testcase := range tests {
+		app := New(func(c *Config) {
+			c.TrustedProxies = []string{"10.0.0.0/8"}
+			if testcase.proxyHeader != "" {
+				c.ProxyHeader = testcase.proxyHeader
+			}
+		})
+
+		req := httptest.NewRequest("GET", "http://example.com/", nil)
+		req.RemoteAddr = testcase.remoteAddr
+		if testcase.tls {
+			req.TLS = &tls.ConnectionState{}
+		}
+		for k, values := range testcase.header {
+			for _, v := range values {
+				req.Header.Add(k, v)
+			}
+		}
+
+		c := app.NewContext(httptest.NewRecorder(), req)
+		assertEqual(t, testcase.scheme, c.Scheme())
+		assertEqual(t, testcase.host, c.Host())
+	}
+}
+
+func TestAppProxyHeaderInvalid(t *testing.T) {
+	defer func() {
+		assertEqual(t, "Invalid proxy header. `X-Client-IP` must be one of `X-Forwarded-For`, `X-Real-IP` or `Forwarded`.", recover())
+	}()
+	New(func(c *Config) {
+		c.ProxyHeader = "X-Client-IP"
+	})
+}
+
+func TestAppTrustedProxiesInvalid(t *testing.T) {
+	for _, proxy := range []string{"invalid", "10.0.0.0/33"} {
+		func() {
+			defer func() {
+				assertNotNil(t, recover())
+			}()
+			New(func(c *Config) {
+				c.TrustedProxies = []string{proxy}
+			})
+		}()
+	}
+}
@@ -0,0 +1,18 @@
+package lungo
+
+import (
+	"errors"
+	"io"
+)
+
+// Renderer is the interface that wraps the Render method.
+//
+// Render writes the template with the given name to w,
+// passing the provided data to the template.
+type Renderer interface {
+	Render(w io.Writer, name string, data any) error
+}
+
+// ErrRendererNotFound is returned by `c.Render()` when
+// no Renderer is stored on the Context.
+var ErrRendererNotFound = errors.New("Render: no renderer registered")
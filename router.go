@@ -13,6 +13,7 @@ type Router struct {
 	mutex       sync.RWMutex
 	routes      map[string]map[string]Route
 	middlewares []Middleware
+	pre         []Middleware
 }
 
 const (
@@ -28,7 +29,7 @@ type Router struct {
 
 // NewRouter allocates and returns a new router instance.
 func NewRouter() *Router {
-	return &Router{routes: make(map[string]map[string]Route), middlewares: make([]Middleware, 0)}
+	return &Router{routes: make(map[string]map[string]Route), middlewares: make([]Middleware, 0), pre: make([]Middleware, 0)}
 }
 
 // Handle registers a Handler for the given path.
@@ -63,6 +64,14 @@ func (router *Router) Use(middlewares ...Middleware) {
 	router.middlewares = append(router.middlewares, middlewares...)
 }
 
+// Pre adds a Middleware to the router, which is executed before the
+// request is routed. In contrast to the middlewares added by `Use()`,
+// these can modify the request in order to affect the routing, e.g.
+// by rewriting the method or path of the request.
+func (router *Router) Pre(middlewares ...Middleware) {
+	router.pre = append(router.pre, middlewares...)
+}
+
 // Find the Route instace for a given path and http method.
 // The Route contains the Handler for this request
 // This function returns nil if no route matches the request path.
@@ -179,6 +188,19 @@ func (router *Router) ServeHTTP(c *Context) error {
 		}
 	}
 
+	// The request is routed after the pre-routing middlewares
+	// were executed, such that they can modify the request.
+	var handler Handler = HandlerFunc(router.route)
+	for _, middleware := range router.pre {
+		handler = middleware(handler)
+	}
+
+	return handler.ServeHTTP(c)
+}
+
+// route dispatches the request to the request handler
+// whose path matches the request URL.
+func (router *Router) route(c *Context) error {
 	// Set the initial handler function to serve the request
 	handler := router.Handler(c.Request)
 
@@ -458,3 +458,37 @@ func TestRouterMiddleware(t *testing.T) {
 	assertEqual(t, "Hello, world!", rr.Body.String())
 	assertEqual(t, "1; mode=blockFilter", header.Get("X-XSS-Protection"))
 }
+
+func TestRouterPre(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := NewRouter()
+
+	router.Handle(Route{
+		Method: http.MethodPut,
+		Path:   "/",
+		Handler: HandlerFunc(func(c *Context) error {
+			return c.Text(http.StatusOK, "Hello, world!")
+		}),
+	})
+
+	router.Pre(func(next Handler) Handler {
+		return HandlerFunc(func(c *Context) error {
+			c.Request.Method = http.MethodPut
+
+			return next.ServeHTTP(c)
+		})
+	})
+
+	router.ServeHTTP(&Context{
+		Request:  req,
+		Response: rr,
+	})
+
+	assertEqual(t, http.StatusOK, rr.Code)
+	assertEqual(t, "Hello, world!", rr.Body.String())
+}